@@ -1,16 +1,23 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	adminConsoleApiV1 "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
 	adminConsoleApiV1aplpha1 "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1alpha1"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/controller/adminconsole"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/controller/helper"
+	commonHelper "github.com/epam/edp-admin-console-operator/v2/pkg/helper"
 	buildInfo "github.com/epam/edp-common/pkg/config"
 	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
 	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
+	appsV1Api "github.com/openshift/api/apps/v1"
+	routeV1Api "github.com/openshift/api/route/v1"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,7 +42,11 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
-const adminConsoleOperatorLock = "edp-admin-console-operator-lock"
+const (
+	adminConsoleOperatorLock = "edp-admin-console-operator-lock"
+	adminConsoleCRDName      = "adminconsoles.v2.edp.epam.com"
+	caCertFileName           = "ca.crt"
+)
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
@@ -47,6 +58,10 @@ func init() {
 	utilruntime.Must(keycloakV1Api.AddToScheme(scheme))
 
 	utilruntime.Must(edpCompApi.AddToScheme(scheme))
+
+	utilruntime.Must(routeV1Api.AddToScheme(scheme))
+
+	utilruntime.Must(appsV1Api.AddToScheme(scheme))
 }
 
 func main() {
@@ -88,14 +103,14 @@ func main() {
 		"platform", v.Platform,
 	)
 
-	ns, err := helper.GetWatchNamespace()
+	namespaces, err := helper.GetWatchNamespaces()
 	if err != nil {
 		setupLog.Error(err, "unable to get watch namespace")
 		os.Exit(1)
 	}
 
 	cfg := ctrl.GetConfigOrDie()
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+	options := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		HealthProbeBindAddress: probeAddr,
@@ -105,8 +120,11 @@ func main() {
 		MapperProvider: func(c *rest.Config) (meta.RESTMapper, error) {
 			return apiutil.NewDynamicRESTMapper(cfg)
 		},
-		Namespace: ns,
-	})
+	}
+	helper.SetWatchNamespaces(&options, namespaces)
+	setupLog.Info("Watching namespaces", "namespaces", namespaces)
+
+	mgr, err := ctrl.NewManager(cfg, options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
@@ -121,7 +139,8 @@ func main() {
 		os.Exit(1)
 	}
 
-	acCtrl, err := adminconsole.NewReconcileAdminConsole(cl, mgr.GetScheme(), ctrl.Log.WithName("controllers"))
+	acCtrl, err := adminconsole.NewReconcileAdminConsole(cl, mgr.GetScheme(), ctrl.Log.WithName("controllers"),
+		mgr.GetEventRecorderFor("admin-console-controller"))
 	if err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "admin-console")
 		os.Exit(1)
@@ -132,6 +151,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	webhooksEnabled, err := helper.GetWebhooksEnabled()
+	if err != nil {
+		setupLog.Error(err, "unable to get webhooks mode value")
+		os.Exit(1)
+	}
+
+	if webhooksEnabled {
+		if err := (&adminConsoleApiV1.AdminConsole{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AdminConsole")
+			os.Exit(1)
+		}
+
+		if err := setupConversionWebhook(cl, mgr.GetWebhookServer().CertDir); err != nil {
+			setupLog.Error(err, "unable to set up conversion webhook", "webhook", "AdminConsole")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -148,3 +185,18 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// setupConversionWebhook switches the AdminConsole CRD conversion to the webhook served by this manager.
+func setupConversionWebhook(c client.Client, certDir string) error {
+	svc, err := helper.GetWebhookService()
+	if err != nil {
+		return err
+	}
+
+	ca, err := ioutil.ReadFile(filepath.Join(certDir, caCertFileName))
+	if err != nil {
+		return errors.Wrap(err, "unable to read webhook CA certificate")
+	}
+
+	return commonHelper.PatchConversionWebhook(context.Background(), c, adminConsoleCRDName, svc, ca)
+}
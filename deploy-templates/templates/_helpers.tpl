@@ -62,35 +62,9 @@ Create the name of the service account to use
 {{- end }}
 {{- end }}
 
-Return the appropriate apiVersion for ingress.
-*/}}
-{{- define "adminConsole.ingress.apiVersion" -}}
-  {{- if and (.Capabilities.APIVersions.Has "networking.k8s.io/v1") (semverCompare ">= 1.19-0" .Capabilities.KubeVersion.Version) -}}
-      {{- print "networking.k8s.io/v1" -}}
-  {{- else if .Capabilities.APIVersions.Has "networking.k8s.io/v1beta1" -}}
-    {{- print "networking.k8s.io/v1beta1" -}}
-  {{- else -}}
-    {{- print "extensions/v1beta1" -}}
-  {{- end -}}
-{{- end -}}
-
-{{/*
-Return if ingress is stable.
-*/}}
-{{- define "adminConsole.ingress.isStable" -}}
-  {{- eq (include "adminConsole.ingress.apiVersion" .) "networking.k8s.io/v1" -}}
-{{- end -}}
-
 {{/*
-Return if ingress supports ingressClassName.
+Whether the operator manages AdminConsoles outside the release namespace and needs cluster-wide permissions
 */}}
-{{- define "adminConsole.ingress.supportsIngressClassName" -}}
-  {{- or (eq (include "adminConsole.ingress.isStable" .) "true") (and (eq (include "adminConsole.ingress.apiVersion" .) "networking.k8s.io/v1beta1") (semverCompare ">= 1.18-0" .Capabilities.KubeVersion.Version)) -}}
-{{- end -}}
-
-{{/*
-Return if ingress supports pathType.
-*/}}
-{{- define "adminConsole.ingress.supportsPathType" -}}
-  {{- or (eq (include "adminConsole.ingress.isStable" .) "true") (and (eq (include "adminConsole.ingress.apiVersion" .) "networking.k8s.io/v1beta1") (semverCompare ">= 1.18-0" .Capabilities.KubeVersion.Version)) -}}
-{{- end -}}
\ No newline at end of file
+{{- define "admin-console-operator.clusterScoped" -}}
+{{- if or .Values.watch.clusterWide .Values.watch.namespaces }}true{{- end }}
+{{- end }}
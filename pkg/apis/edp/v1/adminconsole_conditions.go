@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types reported in AdminConsoleStatus.Conditions.
+const (
+	// ConditionSecretsReady reports whether the admin-console-reader and Keycloak client secrets exist.
+	ConditionSecretsReady = "SecretsReady"
+	// ConditionKeycloakClientReady reports whether the KeycloakClient CR and its parent Keycloak CRs exist.
+	ConditionKeycloakClientReady = "KeycloakClientReady"
+	// ConditionDeploymentReady reports whether the admin console Deployment or DeploymentConfig is available.
+	ConditionDeploymentReady = "DeploymentReady"
+	// ConditionDatabaseProvisioned reports whether the database, the schema and the reader role have been provisioned.
+	ConditionDatabaseProvisioned = "DatabaseProvisioned"
+	// ConditionDatabaseReady reports whether the operator can connect to the database with the configured credentials,
+	// it is Unknown when no credentials are configured.
+	ConditionDatabaseReady = "DatabaseReady"
+	// ConditionEnvPatched reports whether the generated environment has been applied to the admin console.
+	ConditionEnvPatched = "EnvPatched"
+	// ConditionEDPComponentPublished reports whether the EDPComponent for the admin console exists.
+	ConditionEDPComponentPublished = "EDPComponentPublished"
+	// ConditionCredentialsRotated reports whether the last requested credentials rotation has succeeded.
+	// It is informational and does not affect the Ready condition.
+	ConditionCredentialsRotated = "CredentialsRotated"
+	// ConditionStalled is True when a step has failed with an error retrying does not fix, e.g. an invalid spec.
+	// The admin console is not reconciled again until its spec changes.
+	ConditionStalled = "Stalled"
+	// ConditionReady is True when all the other conditions are True.
+	ConditionReady = "Ready"
+)
+
+// Condition reasons reported in AdminConsoleStatus.Conditions.
+const (
+	ReasonSecretsCreated               = "SecretsCreated"
+	ReasonSecretsCreationFailed        = "SecretsCreationFailed"
+	ReasonKeycloakDisabled             = "KeycloakDisabled"
+	ReasonKeycloakClientCreated        = "KeycloakClientCreated"
+	ReasonKeycloakClientCreationFailed = "KeycloakClientCreationFailed"
+	ReasonKeycloakNotFound             = "KeycloakNotFound"
+	ReasonExposureNotFound             = "ExposureNotFound"
+	ReasonDeploymentAvailable          = "DeploymentAvailable"
+	ReasonDeploymentNotReady           = "DeploymentNotReady"
+	ReasonProgressDeadlineExceeded     = "ProgressDeadlineExceeded"
+	ReasonDeploymentReconcileFailed    = "DeploymentReconcileFailed"
+	ReasonDeploymentCheckFailed        = "DeploymentCheckFailed"
+	ReasonEnvPatched                   = "EnvPatched"
+	ReasonEnvPatchFailed               = "EnvPatchFailed"
+	ReasonEnvConflict                  = "EnvConflict"
+	ReasonFeaturesInvalid              = "FeaturesInvalid"
+	ReasonDbSettingsInvalid            = "DbSettingsInvalid"
+	ReasonDatabaseDisabled             = "DatabaseDisabled"
+	ReasonDatabaseConnected            = "DatabaseConnected"
+	ReasonDatabaseConnectionFailed     = "DatabaseConnectionFailed"
+	ReasonDbCredentialsNotSet          = "DbCredentialsNotSet"
+	ReasonProvisioningDisabled         = "ProvisioningDisabled"
+	ReasonDatabaseProvisioned          = "DatabaseProvisioned"
+	ReasonDatabaseProvisioningFailed   = "DatabaseProvisioningFailed"
+	ReasonEDPComponentPublished        = "EDPComponentPublished"
+	ReasonEDPComponentPublishFailed    = "EDPComponentPublishFailed"
+	ReasonEDPComponentsNotListed       = "EDPComponentsNotListed"
+	ReasonCredentialsRotated           = "CredentialsRotated"
+	ReasonCredentialsRotationFailed    = "CredentialsRotationFailed"
+	ReasonReconcileSucceeded           = "ReconcileSucceeded"
+	ReasonReconcileInProgress          = "ReconcileInProgress"
+	ReasonPermanentError               = "PermanentError"
+)
+
+// SetCondition adds or updates the condition of the given type, stamping it with the current generation.
+func (in *AdminConsole) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&in.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: in.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// SetConditionTrue marks the condition of the given type as True.
+func (in *AdminConsole) SetConditionTrue(conditionType, reason, message string) {
+	in.SetCondition(conditionType, metav1.ConditionTrue, reason, message)
+}
+
+// SetConditionFalse marks the condition of the given type as False.
+func (in *AdminConsole) SetConditionFalse(conditionType, reason, message string) {
+	in.SetCondition(conditionType, metav1.ConditionFalse, reason, message)
+}
+
+// IsConditionTrue reports whether the condition of the given type is present and True.
+func (in *AdminConsole) IsConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(in.Status.Conditions, conditionType)
+}
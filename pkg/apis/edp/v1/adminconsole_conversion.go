@@ -0,0 +1,4 @@
+package v1
+
+// Hub marks v1 as the conversion hub, v1alpha1 converts to and from it.
+func (*AdminConsole) Hub() {}
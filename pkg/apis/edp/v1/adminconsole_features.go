@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Values accepted in the feature lists of spec.features.
+var (
+	KnownIntegrationStrategies = []string{"Create", "Clone", "Import"}
+	KnownBuildTools            = []string{"maven", "gradle", "npm", "dotnet", "go", "python"}
+	KnownDeploymentScripts     = []string{"helm-chart", "openshift-template"}
+	KnownVersioningTypes       = []string{"default", "edp"}
+	KnownCiTools               = []string{"Jenkins", "GitLab CI"}
+	KnownPerfDataSources       = []string{"Sonar", "Jenkins", "GitLab"}
+)
+
+// FeatureList is a feature list of spec.features with the environment variable it is passed to the admin console in.
+// +kubebuilder:object:generate=false
+type FeatureList struct {
+	Field  string
+	EnvVar string
+	Values []string
+	Known  []string
+}
+
+// FeatureLists returns the feature lists of the spec in the order their environment variables are generated.
+func (in *FeaturesSpec) FeatureLists() []FeatureList {
+	if in == nil {
+		return nil
+	}
+
+	return []FeatureList{
+		{Field: "integrationStrategies", EnvVar: "INTEGRATION_STRATEGIES", Values: in.IntegrationStrategies, Known: KnownIntegrationStrategies},
+		{Field: "buildTools", EnvVar: "BUILD_TOOLS", Values: in.BuildTools, Known: KnownBuildTools},
+		{Field: "deploymentScripts", EnvVar: "DEPLOYMENT_SCRIPT", Values: in.DeploymentScripts, Known: KnownDeploymentScripts},
+		{Field: "versioningTypes", EnvVar: "VERSIONING_TYPES", Values: in.VersioningTypes, Known: KnownVersioningTypes},
+		{Field: "ciTools", EnvVar: "CI_TOOLS", Values: in.CiTools, Known: KnownCiTools},
+		{Field: "perfDataSources", EnvVar: "PERF_DATA_SOURCES", Values: in.PerfDataSources, Known: KnownPerfDataSources},
+	}
+}
+
+// ValidateFeatures returns the unknown and the duplicate values of the feature lists.
+func ValidateFeatures(features *FeaturesSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	for _, list := range features.FeatureLists() {
+		seen := map[string]bool{}
+		for i, v := range list.Values {
+			p := path.Child(list.Field).Index(i)
+			if !containsString(list.Known, v) {
+				errs = append(errs, field.NotSupported(p, v, list.Known))
+			}
+			if seen[v] {
+				errs = append(errs, field.Duplicate(p, v))
+			}
+			seen[v] = true
+		}
+	}
+
+	return errs
+}
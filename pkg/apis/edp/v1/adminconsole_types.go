@@ -1,11 +1,63 @@
 package v1
 
 import (
+	coreV1Api "k8s.io/api/core/v1"
+	networkingV1Api "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // AdminConsoleSpec defines the desired state of AdminConsole
 type AdminConsoleSpec struct {
+	// Image is the admin console docker image name.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Version is the admin console docker image tag.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +nullable
+	// +optional
+	ImagePullSecrets []coreV1Api.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Replicas is the number of desired admin console pods.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Availability configures the autoscaling and the disruption budget of the admin console pods.
+	// +optional
+	Availability *AvailabilitySpec `json:"availability,omitempty"`
+	// Port is the port the admin console listens on and the Service exposes.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Env is a list of additional environment variables for the admin console container.
+	// +nullable
+	// +optional
+	Env []coreV1Api.EnvVar `json:"env,omitempty"`
+	// +optional
+	Resources coreV1Api.ResourceRequirements `json:"resources,omitempty"`
+	// +nullable
+	// +optional
+	Volumes []coreV1Api.Volume `json:"volumes,omitempty"`
+	// +nullable
+	// +optional
+	VolumeMounts []coreV1Api.VolumeMount `json:"volumeMounts,omitempty"`
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +optional
+	Affinity *coreV1Api.Affinity `json:"affinity,omitempty"`
+	// +nullable
+	// +optional
+	Tolerations []coreV1Api.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	Ingress IngressSpec `json:"ingress,omitempty"`
+	// Exposure selects how the admin console is exposed outside the cluster: an Ingress, an OpenShift Route
+	// or a Gateway API HTTPRoute. Defaults to ingress on Kubernetes and to route on OpenShift.
+	// +kubebuilder:validation:Enum=ingress;route;httpRoute
+	// +optional
+	Exposure string `json:"exposure,omitempty"`
+	// Gateway configures the HTTPRoute of the httpRoute exposure.
+	// +optional
+	Gateway *GatewaySpec `json:"gateway,omitempty"`
 	// +optional
 	KeycloakSpec KeycloakSpec `json:"keycloakSpec,omitempty"`
 	EdpSpec      EdpSpec      `json:"edpSpec"`
@@ -13,6 +65,141 @@ type AdminConsoleSpec struct {
 	DbSpec AdminConsoleDbSettings `json:"dbSpec,omitempty"`
 	// +optional
 	BasePath string `json:"basePath,omitempty"`
+	// DeletionPolicy defines whether the Keycloak client, the EDPComponent and the generated secrets
+	// are deleted together with the AdminConsole or retained.
+	// +kubebuilder:validation:Enum=delete;retain
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+	// Rotation configures the rotation of the generated admin-console-reader and Keycloak client credentials.
+	// Credentials are also rotated on demand when the rotate-credentials annotation changes. The reader password
+	// is rotated only with dbSpec.provisioning, the operator has no credentials to change the role otherwise.
+	// +optional
+	Rotation *RotationPolicy `json:"rotation,omitempty"`
+	// Features lists the options the admin console offers when a codebase is added.
+	// +optional
+	Features *FeaturesSpec `json:"features,omitempty"`
+	// ConfigSource selects how the settings generated during integration reach the admin console: as env of the
+	// container or through a ConfigMap owned by the AdminConsole and referenced with envFrom. Settings read from
+	// secrets are always passed as env. Defaults to env.
+	// +kubebuilder:validation:Enum=env;configMap
+	// +optional
+	ConfigSource string `json:"configSource,omitempty"`
+}
+
+// FeaturesSpec lists the options the admin console offers when a codebase is added. Every list is passed to the
+// admin console as a comma-separated environment variable, the defaults of the admin console apply to an empty list.
+type FeaturesSpec struct {
+	// IntegrationStrategies are the ways a codebase is added in: Create, Clone and Import.
+	// +optional
+	IntegrationStrategies []string `json:"integrationStrategies,omitempty"`
+	// BuildTools of the codebases: maven, gradle, npm, dotnet, go and python.
+	// +optional
+	BuildTools []string `json:"buildTools,omitempty"`
+	// DeploymentScripts the applications are deployed with: helm-chart and openshift-template.
+	// +optional
+	DeploymentScripts []string `json:"deploymentScripts,omitempty"`
+	// VersioningTypes of the codebases: default and edp.
+	// +optional
+	VersioningTypes []string `json:"versioningTypes,omitempty"`
+	// CiTools running the codebase pipelines: Jenkins and GitLab CI.
+	// +optional
+	CiTools []string `json:"ciTools,omitempty"`
+	// PerfDataSources the performance metrics of the codebases are collected from: Sonar, Jenkins and GitLab.
+	// +optional
+	PerfDataSources []string `json:"perfDataSources,omitempty"`
+}
+
+// AvailabilitySpec configures the autoscaling of the admin console pods and how many of them survive voluntary
+// disruptions. Without autoscaling spec.replicas pods run.
+type AvailabilitySpec struct {
+	// MinReplicas is the lower autoscaling bound, 1 by default.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper autoscaling bound. A HorizontalPodAutoscaler is created when it is set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+	// TargetCPUUtilizationPercentage is the average CPU utilization the autoscaler aims at, 80 by default.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// MinAvailable is the number or the percentage of pods kept running during voluntary disruptions
+	// such as node drains. A PodDisruptionBudget is created when it is set.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or the percentage of pods allowed to be down during voluntary disruptions.
+	// A PodDisruptionBudget is created when it is set, it is mutually exclusive with minAvailable.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// RotateCredentialsAnnotation requests a credentials rotation whenever its value changes.
+const RotateCredentialsAnnotation = "v2.edp.epam.com/rotate-credentials"
+
+// RotationPolicy defines when the generated credentials are rotated.
+type RotationPolicy struct {
+	// Interval between scheduled rotations, e.g. "2160h" for 90 days.
+	// Credentials are only rotated on demand when it is not set.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+const (
+	// DeletionPolicyDelete removes the dependent objects when the AdminConsole is deleted.
+	DeletionPolicyDelete = "delete"
+	// DeletionPolicyRetain leaves the dependent objects in place when the AdminConsole is deleted.
+	DeletionPolicyRetain = "retain"
+)
+
+const (
+	// ConfigSourceEnv sets the generated settings as env of the admin console container.
+	ConfigSourceEnv = "env"
+	// ConfigSourceConfigMap renders the generated settings into a ConfigMap the admin console container references.
+	ConfigSourceConfigMap = "configMap"
+)
+
+// IngressSpec configures how the admin console is exposed outside the cluster.
+type IngressSpec struct {
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// +nullable
+	// +optional
+	TLS []networkingV1Api.IngressTLS `json:"tls,omitempty"`
+}
+
+const (
+	// ExposureIngress exposes the admin console with a networking/v1 Ingress.
+	ExposureIngress = "ingress"
+	// ExposureRoute exposes the admin console with an OpenShift Route.
+	ExposureRoute = "route"
+	// ExposureHTTPRoute exposes the admin console with a Gateway API HTTPRoute.
+	ExposureHTTPRoute = "httpRoute"
+)
+
+// GatewaySpec configures the Gateway API HTTPRoute which exposes the admin console.
+type GatewaySpec struct {
+	// ParentRefs are the Gateways the HTTPRoute is attached to.
+	// +optional
+	ParentRefs []GatewayParentRef `json:"parentRefs,omitempty"`
+	// RouteName is the name of an HTTPRoute managed outside the operator to read the admin console URL from.
+	// The operator creates an HTTPRoute named after the AdminConsole when it is not set.
+	// +optional
+	RouteName string `json:"routeName,omitempty"`
+}
+
+// GatewayParentRef references a Gateway listener the HTTPRoute is attached to.
+type GatewayParentRef struct {
+	Name string `json:"name"`
+	// Namespace of the Gateway, the namespace of the AdminConsole by default.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the Gateway listener.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
 }
 
 type EdpSpec struct {
@@ -25,6 +212,35 @@ type EdpSpec struct {
 type KeycloakSpec struct {
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
+	// RealmRef is the name of the KeycloakRealm CR the client is created in.
+	// The Keycloak operator picks the main realm when it is not set.
+	// +optional
+	RealmRef string `json:"realmRef,omitempty"`
+	// ClientId is the Keycloak client ID, "admin-console-client" by default.
+	// +optional
+	ClientId string `json:"clientId,omitempty"`
+	// RealmRoles are the realm roles of the client service account, "developer" by default.
+	// +optional
+	RealmRoles []string `json:"realmRoles,omitempty"`
+	// ClientRoles are the roles of other clients granted to the client service account.
+	// +optional
+	ClientRoles []KeycloakClientRoles `json:"clientRoles,omitempty"`
+	// DefaultClientScopes are the default client scopes of the client, "edp" by default.
+	// +optional
+	DefaultClientScopes []string `json:"defaultClientScopes,omitempty"`
+	// Public makes the client public instead of confidential. Public clients have no secret and no service account.
+	// +optional
+	Public bool `json:"public,omitempty"`
+	// DirectAccess enables direct access grants for the client, true by default.
+	// +optional
+	DirectAccess *bool `json:"directAccess,omitempty"`
+}
+
+// KeycloakClientRoles lists the roles of a Keycloak client.
+type KeycloakClientRoles struct {
+	ClientId string `json:"clientId"`
+	// +optional
+	Roles []string `json:"roles,omitempty"`
 }
 
 type AdminConsoleDbSettings struct {
@@ -36,6 +252,48 @@ type AdminConsoleDbSettings struct {
 	Port string `json:"port,omitempty"`
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
+	// CredentialsSecretRef references the secret with the database user credentials.
+	// +optional
+	CredentialsSecretRef *DbCredentialsSecretRef `json:"credentialsSecretRef,omitempty"`
+	// SslMode is the libpq sslmode used to connect to the database.
+	// +kubebuilder:validation:Enum=disable;require;verify-ca;verify-full
+	// +optional
+	SslMode string `json:"sslMode,omitempty"`
+	// CaSecretRef references the secret key with the CA certificate of the database server.
+	// +optional
+	CaSecretRef *coreV1Api.SecretKeySelector `json:"caSecretRef,omitempty"`
+	// Provisioning enables creation of the database, the schema and the read-only role by the operator.
+	// +optional
+	Provisioning *DbProvisioning `json:"provisioning,omitempty"`
+}
+
+// DbProvisioning configures how the operator provisions the admin console database.
+type DbProvisioning struct {
+	// AdminCredentialsSecretRef references the secret with the credentials of a user
+	// allowed to create databases and roles.
+	AdminCredentialsSecretRef DbCredentialsSecretRef `json:"adminCredentialsSecretRef"`
+	// Schema is the schema to create, edpSpec.name by default.
+	// +optional
+	Schema string `json:"schema,omitempty"`
+}
+
+// SSL modes accepted in dbSpec.sslMode.
+const (
+	SslModeDisable    = "disable"
+	SslModeRequire    = "require"
+	SslModeVerifyCA   = "verify-ca"
+	SslModeVerifyFull = "verify-full"
+)
+
+// DbCredentialsSecretRef references the secret keys with the database user name and password.
+type DbCredentialsSecretRef struct {
+	Name string `json:"name"`
+	// UsernameKey is the secret key with the user name, "username" by default.
+	// +optional
+	UsernameKey string `json:"usernameKey,omitempty"`
+	// PasswordKey is the secret key with the password, "password" by default.
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
 }
 
 // AdminConsoleStatus defines the observed state of AdminConsole
@@ -44,13 +302,30 @@ type AdminConsoleStatus struct {
 	Available bool `json:"available,omitempty"`
 	// +optional
 	LastTimeUpdated metav1.Time `json:"lastTimeUpdated,omitempty"`
+	// LastRotationTime is the time the generated credentials were last rotated.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// LastRotationRequest is the value of the rotate-credentials annotation handled by the last rotation.
+	// +optional
+	LastRotationRequest string `json:"lastRotationRequest,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the state of each step of the admin console reconciliation.
 	// +optional
-	Status string `json:"status,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // AdminConsole is the Schema for the adminconsoles API
 type AdminConsole struct {
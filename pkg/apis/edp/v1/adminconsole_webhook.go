@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	// DefaultDbPort is the PostgreSQL port used when dbSpec.port is not set.
+	DefaultDbPort = "5432"
+	// DefaultDbUsernameKey is the credentials secret key with the database user name.
+	DefaultDbUsernameKey = "username"
+	// DefaultDbPasswordKey is the credentials secret key with the database password.
+	DefaultDbPasswordKey = "password"
+	// DefaultDbCaKey is the CA secret key with the database server CA certificate.
+	DefaultDbCaKey = "ca.crt"
+	// TestReportToolsAllure is the only test report tool the admin console supports.
+	TestReportToolsAllure = "Allure"
+)
+
+// KnownTestReportTools lists the values accepted in edpSpec.testReportTools.
+var KnownTestReportTools = []string{TestReportToolsAllure}
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks for AdminConsole.
+func (in *AdminConsole) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(in).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-v2-edp-epam-com-v1-adminconsole,mutating=true,failurePolicy=fail,sideEffects=None,groups=v2.edp.epam.com,resources=adminconsoles,verbs=create;update,versions=v1,name=madminconsole.v2.edp.epam.com,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &AdminConsole{}
+
+// Default fills in the dbSpec port and secret keys and edpSpec.name when they are not set.
+func (in *AdminConsole) Default() {
+	if in.Spec.DbSpec.Port == "" {
+		in.Spec.DbSpec.Port = DefaultDbPort
+	}
+
+	if ref := in.Spec.DbSpec.CredentialsSecretRef; ref != nil {
+		if ref.UsernameKey == "" {
+			ref.UsernameKey = DefaultDbUsernameKey
+		}
+		if ref.PasswordKey == "" {
+			ref.PasswordKey = DefaultDbPasswordKey
+		}
+	}
+
+	if p := in.Spec.DbSpec.Provisioning; p != nil {
+		if p.AdminCredentialsSecretRef.UsernameKey == "" {
+			p.AdminCredentialsSecretRef.UsernameKey = DefaultDbUsernameKey
+		}
+		if p.AdminCredentialsSecretRef.PasswordKey == "" {
+			p.AdminCredentialsSecretRef.PasswordKey = DefaultDbPasswordKey
+		}
+	}
+
+	if ref := in.Spec.DbSpec.CaSecretRef; ref != nil && ref.Key == "" {
+		ref.Key = DefaultDbCaKey
+	}
+
+	if in.Spec.EdpSpec.Name == "" {
+		in.Spec.EdpSpec.Name = in.Namespace
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-v2-edp-epam-com-v1-adminconsole,mutating=false,failurePolicy=fail,sideEffects=None,groups=v2.edp.epam.com,resources=adminconsoles,verbs=create;update,versions=v1,name=vadminconsole.v2.edp.epam.com,admissionReviewVersions=v1
+
+var _ webhook.Validator = &AdminConsole{}
+
+// ValidateCreate checks the spec of a new AdminConsole.
+func (in *AdminConsole) ValidateCreate() error {
+	return in.validate()
+}
+
+// ValidateUpdate checks the spec of an updated AdminConsole. Only the problems the update introduces are reported,
+// so an object made invalid by newer rules still accepts the updates of the operator (finalizer, status) and fixes.
+// Objects being deleted are not validated, so the finalizer can always be removed.
+func (in *AdminConsole) ValidateUpdate(old runtime.Object) error {
+	if in.DeletionTimestamp != nil {
+		return nil
+	}
+
+	oldAc, ok := old.(*AdminConsole)
+	if !ok {
+		return in.validate()
+	}
+	if equality.Semantic.DeepEqual(oldAc.Spec, in.Spec) {
+		return nil
+	}
+
+	existing := ValidateAdminConsoleSpec(&oldAc.Spec, field.NewPath("spec"))
+	var errs field.ErrorList
+	for _, err := range ValidateAdminConsoleSpec(&in.Spec, field.NewPath("spec")) {
+		if !containsError(existing, err) {
+			errs = append(errs, err)
+		}
+	}
+	return in.invalid(errs)
+}
+
+// ValidateDelete allows every deletion.
+func (in *AdminConsole) ValidateDelete() error {
+	return nil
+}
+
+func (in *AdminConsole) validate() error {
+	return in.invalid(ValidateAdminConsoleSpec(&in.Spec, field.NewPath("spec")))
+}
+
+func (in *AdminConsole) invalid(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(SchemeGroupVersion.WithKind("AdminConsole").GroupKind(), in.Name, errs)
+}
+
+// ValidateAdminConsoleSpec returns the list of problems found in the spec.
+func ValidateAdminConsoleSpec(spec *AdminConsoleSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if spec.DbSpec.Enabled {
+		dbPath := path.Child("dbSpec")
+		if spec.DbSpec.Hostname == "" {
+			errs = append(errs, field.Required(dbPath.Child("hostname"), "required when the database is enabled"))
+		}
+		if spec.DbSpec.Name == "" {
+			errs = append(errs, field.Required(dbPath.Child("name"), "required when the database is enabled"))
+		}
+		if spec.DbSpec.Port == "" {
+			errs = append(errs, field.Required(dbPath.Child("port"), "required when the database is enabled"))
+		} else if port, err := strconv.Atoi(spec.DbSpec.Port); err != nil || validation.IsValidPortNum(port) != nil {
+			errs = append(errs, field.Invalid(dbPath.Child("port"), spec.DbSpec.Port, "must be a number between 1 and 65535"))
+		}
+		if ref := spec.DbSpec.CredentialsSecretRef; ref != nil && ref.Name == "" {
+			errs = append(errs, field.Required(dbPath.Child("credentialsSecretRef", "name"), "must reference a secret"))
+		}
+		if p := spec.DbSpec.Provisioning; p != nil && p.AdminCredentialsSecretRef.Name == "" {
+			errs = append(errs, field.Required(dbPath.Child("provisioning", "adminCredentialsSecretRef", "name"), "must reference a secret"))
+		}
+		if ref := spec.DbSpec.CaSecretRef; ref != nil {
+			if ref.Name == "" {
+				errs = append(errs, field.Required(dbPath.Child("caSecretRef", "name"), "must reference a secret"))
+			}
+			if spec.DbSpec.SslMode != SslModeVerifyCA && spec.DbSpec.SslMode != SslModeVerifyFull {
+				errs = append(errs, field.Invalid(dbPath.Child("sslMode"), spec.DbSpec.SslMode,
+					"must be verify-ca or verify-full when caSecretRef is set"))
+			}
+		}
+	}
+
+	keycloakPath := path.Child("keycloakSpec")
+	if spec.KeycloakSpec.Public {
+		if len(spec.KeycloakSpec.RealmRoles) != 0 {
+			errs = append(errs, field.Forbidden(keycloakPath.Child("realmRoles"), "public clients have no service account"))
+		}
+		if len(spec.KeycloakSpec.ClientRoles) != 0 {
+			errs = append(errs, field.Forbidden(keycloakPath.Child("clientRoles"), "public clients have no service account"))
+		}
+	}
+
+	edpPath := path.Child("edpSpec")
+	for _, msg := range validation.IsDNS1123Subdomain(spec.EdpSpec.DnsWildcard) {
+		errs = append(errs, field.Invalid(edpPath.Child("dnsWildcard"), spec.EdpSpec.DnsWildcard, msg))
+	}
+
+	if !containsString(KnownTestReportTools, spec.EdpSpec.TestReportTools) {
+		errs = append(errs, field.NotSupported(edpPath.Child("testReportTools"), spec.EdpSpec.TestReportTools, KnownTestReportTools))
+	}
+
+	errs = append(errs, ValidateFeatures(spec.Features, path.Child("features"))...)
+
+	if a := spec.Availability; a != nil {
+		if a.MaxReplicas > 0 && a.MinReplicas != nil && *a.MinReplicas > a.MaxReplicas {
+			errs = append(errs, field.Invalid(path.Child("availability", "minReplicas"), *a.MinReplicas, "must not be greater than maxReplicas"))
+		}
+		if a.MinAvailable != nil && a.MaxUnavailable != nil {
+			errs = append(errs, field.Forbidden(path.Child("availability", "maxUnavailable"), "must not be set together with minAvailable"))
+		}
+	}
+
+	if spec.Exposure == ExposureHTTPRoute {
+		gatewayPath := path.Child("gateway")
+		if spec.Gateway == nil || (len(spec.Gateway.ParentRefs) == 0 && spec.Gateway.RouteName == "") {
+			errs = append(errs, field.Required(gatewayPath.Child("parentRefs"), "required with the httpRoute exposure unless routeName is set"))
+		} else {
+			for i, ref := range spec.Gateway.ParentRefs {
+				if ref.Name == "" {
+					errs = append(errs, field.Required(gatewayPath.Child("parentRefs").Index(i).Child("name"), "must reference a gateway"))
+				}
+			}
+		}
+	}
+
+	if spec.BasePath != "" && !strings.HasPrefix(spec.BasePath, "/") {
+		errs = append(errs, field.Invalid(path.Child("basePath"), spec.BasePath, "must start with a slash"))
+	}
+
+	return errs
+}
+
+// containsError reports whether the same problem, with the same value, is in the list.
+func containsError(errs field.ErrorList, err *field.Error) bool {
+	for _, e := range errs {
+		if e.Type == err.Type && e.Field == err.Field && equality.Semantic.DeepEqual(e.BadValue, err.BadValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func validAdminConsole() *AdminConsole {
+	return &AdminConsole{
+		ObjectMeta: metav1.ObjectMeta{Name: "edp-admin-console", Namespace: "edp"},
+		Spec: AdminConsoleSpec{
+			EdpSpec: EdpSpec{Name: "edp", DnsWildcard: "example.com", TestReportTools: TestReportToolsAllure},
+		},
+	}
+}
+
+// causeFields returns the fields of the causes of the Invalid error.
+func causeFields(err error) []string {
+	var fields []string
+	if status, ok := err.(apierrors.APIStatus); ok && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			fields = append(fields, cause.Field)
+		}
+	}
+	return fields
+}
+
+func TestAdminConsole_Default(t *testing.T) {
+	g := NewWithT(t)
+
+	ac := &AdminConsole{
+		ObjectMeta: metav1.ObjectMeta{Name: "edp-admin-console", Namespace: "edp-delivery"},
+		Spec: AdminConsoleSpec{DbSpec: AdminConsoleDbSettings{
+			CredentialsSecretRef: &DbCredentialsSecretRef{Name: "admin-console-db"},
+			Provisioning:         &DbProvisioning{AdminCredentialsSecretRef: DbCredentialsSecretRef{Name: "postgres-admin"}},
+		}},
+	}
+	ac.Default()
+
+	g.Expect(ac.Spec.EdpSpec.Name).Should(Equal("edp-delivery"))
+	g.Expect(ac.Spec.DbSpec.Port).Should(Equal(DefaultDbPort))
+	g.Expect(*ac.Spec.DbSpec.CredentialsSecretRef).Should(Equal(DbCredentialsSecretRef{
+		Name: "admin-console-db", UsernameKey: DefaultDbUsernameKey, PasswordKey: DefaultDbPasswordKey,
+	}))
+	g.Expect(ac.Spec.DbSpec.Provisioning.AdminCredentialsSecretRef.UsernameKey).Should(Equal(DefaultDbUsernameKey))
+	g.Expect(ac.Spec.DbSpec.Provisioning.AdminCredentialsSecretRef.PasswordKey).Should(Equal(DefaultDbPasswordKey))
+
+	ac.Spec.EdpSpec.Name = "edp"
+	ac.Default()
+	g.Expect(ac.Spec.EdpSpec.Name).Should(Equal("edp"))
+}
+
+func TestAdminConsole_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(ac *AdminConsole)
+		wantFields []string
+	}{
+		{
+			name:   "valid",
+			modify: func(ac *AdminConsole) {},
+		},
+		{
+			name:       "bad dnsWildcard",
+			modify:     func(ac *AdminConsole) { ac.Spec.EdpSpec.DnsWildcard = "Example_com" },
+			wantFields: []string{"spec.edpSpec.dnsWildcard"},
+		},
+		{
+			name:       "unknown testReportTools",
+			modify:     func(ac *AdminConsole) { ac.Spec.EdpSpec.TestReportTools = "ReportPortal" },
+			wantFields: []string{"spec.edpSpec.testReportTools"},
+		},
+		{
+			name:   "keycloak enabled without a realm uses the main realm",
+			modify: func(ac *AdminConsole) { ac.Spec.KeycloakSpec.Enabled = true },
+		},
+		{
+			name: "public keycloak client with realm roles",
+			modify: func(ac *AdminConsole) {
+				ac.Spec.KeycloakSpec = KeycloakSpec{Enabled: true, Public: true, RealmRoles: []string{"developer"}}
+			},
+			wantFields: []string{"spec.keycloakSpec.realmRoles"},
+		},
+		{
+			name: "database enabled without connection settings",
+			modify: func(ac *AdminConsole) {
+				ac.Spec.DbSpec = AdminConsoleDbSettings{Enabled: true, Port: "65536"}
+			},
+			wantFields: []string{"spec.dbSpec.hostname", "spec.dbSpec.name", "spec.dbSpec.port"},
+		},
+		{
+			name: "minAvailable together with maxUnavailable",
+			modify: func(ac *AdminConsole) {
+				one := intstr.FromInt(1)
+				ac.Spec.Availability = &AvailabilitySpec{MinAvailable: &one, MaxUnavailable: &one}
+			},
+			wantFields: []string{"spec.availability.maxUnavailable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ac := validAdminConsole()
+			tt.modify(ac)
+
+			err := ac.ValidateCreate()
+			if len(tt.wantFields) == 0 {
+				g.Expect(err).ShouldNot(HaveOccurred())
+				return
+			}
+			g.Expect(apierrors.IsInvalid(err)).Should(BeTrue())
+			g.Expect(causeFields(err)).Should(Equal(tt.wantFields))
+		})
+	}
+}
+
+func TestAdminConsole_ValidateUpdate(t *testing.T) {
+	// invalidOld has a dnsWildcard admitted before it was validated.
+	invalidOld := validAdminConsole()
+	invalidOld.Spec.EdpSpec.DnsWildcard = "Example_com"
+
+	tests := []struct {
+		name       string
+		old        *AdminConsole
+		modify     func(ac *AdminConsole)
+		wantFields []string
+	}{
+		{
+			name:   "pre-existing error left untouched",
+			old:    invalidOld,
+			modify: func(ac *AdminConsole) { ac.Spec.Version = "2.13.0" },
+		},
+		{
+			name:   "metadata changed only",
+			old:    invalidOld,
+			modify: func(ac *AdminConsole) { ac.Finalizers = []string{"finalizer"} },
+		},
+		{
+			name:       "new error next to a pre-existing one",
+			old:        invalidOld,
+			modify:     func(ac *AdminConsole) { ac.Spec.EdpSpec.TestReportTools = "ReportPortal" },
+			wantFields: []string{"spec.edpSpec.testReportTools"},
+		},
+		{
+			name:       "pre-existing error changed",
+			old:        invalidOld,
+			modify:     func(ac *AdminConsole) { ac.Spec.EdpSpec.DnsWildcard = "Other_com" },
+			wantFields: []string{"spec.edpSpec.dnsWildcard"},
+		},
+		{
+			name: "object being deleted",
+			old:  validAdminConsole(),
+			modify: func(ac *AdminConsole) {
+				now := metav1.Now()
+				ac.DeletionTimestamp = &now
+				ac.Spec.EdpSpec.DnsWildcard = "Example_com"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ac := tt.old.DeepCopy()
+			tt.modify(ac)
+
+			err := ac.ValidateUpdate(tt.old)
+			if len(tt.wantFields) == 0 {
+				g.Expect(err).ShouldNot(HaveOccurred())
+				return
+			}
+			g.Expect(apierrors.IsInvalid(err)).Should(BeTrue())
+			g.Expect(causeFields(err)).Should(Equal(tt.wantFields))
+		})
+	}
+}
@@ -6,7 +6,11 @@
 package v1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -14,7 +18,7 @@ func (in *AdminConsole) DeepCopyInto(out *AdminConsole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -39,6 +43,21 @@ func (in *AdminConsole) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdminConsoleDbSettings) DeepCopyInto(out *AdminConsoleDbSettings) {
 	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(DbCredentialsSecretRef)
+		**out = **in
+	}
+	if in.CaSecretRef != nil {
+		in, out := &in.CaSecretRef, &out.CaSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(DbProvisioning)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminConsoleDbSettings.
@@ -86,9 +105,81 @@ func (in *AdminConsoleList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdminConsoleSpec) DeepCopyInto(out *AdminConsoleSpec) {
 	*out = *in
-	out.KeycloakSpec = in.KeycloakSpec
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Availability != nil {
+		in, out := &in.Availability, &out.Availability
+		*out = new(AvailabilitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewaySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.KeycloakSpec.DeepCopyInto(&out.KeycloakSpec)
 	out.EdpSpec = in.EdpSpec
-	out.DbSpec = in.DbSpec
+	in.DbSpec.DeepCopyInto(&out.DbSpec)
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(RotationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = new(FeaturesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminConsoleSpec.
@@ -105,6 +196,17 @@ func (in *AdminConsoleSpec) DeepCopy() *AdminConsoleSpec {
 func (in *AdminConsoleStatus) DeepCopyInto(out *AdminConsoleStatus) {
 	*out = *in
 	in.LastTimeUpdated.DeepCopyInto(&out.LastTimeUpdated)
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminConsoleStatus.
@@ -117,6 +219,72 @@ func (in *AdminConsoleStatus) DeepCopy() *AdminConsoleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailabilitySpec) DeepCopyInto(out *AvailabilitySpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailabilitySpec.
+func (in *AvailabilitySpec) DeepCopy() *AvailabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DbCredentialsSecretRef) DeepCopyInto(out *DbCredentialsSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DbCredentialsSecretRef.
+func (in *DbCredentialsSecretRef) DeepCopy() *DbCredentialsSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DbCredentialsSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DbProvisioning) DeepCopyInto(out *DbProvisioning) {
+	*out = *in
+	out.AdminCredentialsSecretRef = in.AdminCredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DbProvisioning.
+func (in *DbProvisioning) DeepCopy() *DbProvisioning {
+	if in == nil {
+		return nil
+	}
+	out := new(DbProvisioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EdpSpec) DeepCopyInto(out *EdpSpec) {
 	*out = *in
@@ -132,9 +300,165 @@ func (in *EdpSpec) DeepCopy() *EdpSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeaturesSpec) DeepCopyInto(out *FeaturesSpec) {
+	*out = *in
+	if in.IntegrationStrategies != nil {
+		in, out := &in.IntegrationStrategies, &out.IntegrationStrategies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BuildTools != nil {
+		in, out := &in.BuildTools, &out.BuildTools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeploymentScripts != nil {
+		in, out := &in.DeploymentScripts, &out.DeploymentScripts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VersioningTypes != nil {
+		in, out := &in.VersioningTypes, &out.VersioningTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CiTools != nil {
+		in, out := &in.CiTools, &out.CiTools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PerfDataSources != nil {
+		in, out := &in.PerfDataSources, &out.PerfDataSources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeaturesSpec.
+func (in *FeaturesSpec) DeepCopy() *FeaturesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FeaturesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentRef) DeepCopyInto(out *GatewayParentRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentRef.
+func (in *GatewayParentRef) DeepCopy() *GatewayParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewaySpec.
+func (in *GatewaySpec) DeepCopy() *GatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = make([]networkingv1.IngressTLS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeycloakClientRoles) DeepCopyInto(out *KeycloakClientRoles) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakClientRoles.
+func (in *KeycloakClientRoles) DeepCopy() *KeycloakClientRoles {
+	if in == nil {
+		return nil
+	}
+	out := new(KeycloakClientRoles)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeycloakSpec) DeepCopyInto(out *KeycloakSpec) {
 	*out = *in
+	if in.RealmRoles != nil {
+		in, out := &in.RealmRoles, &out.RealmRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClientRoles != nil {
+		in, out := &in.ClientRoles, &out.ClientRoles
+		*out = make([]KeycloakClientRoles, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultClientScopes != nil {
+		in, out := &in.DefaultClientScopes, &out.DefaultClientScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DirectAccess != nil {
+		in, out := &in.DirectAccess, &out.DirectAccess
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeycloakSpec.
@@ -146,3 +470,23 @@ func (in *KeycloakSpec) DeepCopy() *KeycloakSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RotationPolicy) DeepCopyInto(out *RotationPolicy) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RotationPolicy.
+func (in *RotationPolicy) DeepCopy() *RotationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RotationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
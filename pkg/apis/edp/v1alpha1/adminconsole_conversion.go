@@ -0,0 +1,175 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	adminConsoleApiV1 "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+// Annotations which keep the fields that only exist in one of the versions, so a round trip is lossless.
+const (
+	// EdpVersionAnnotation keeps v1alpha1 spec.edpSpec.version on a v1 object.
+	EdpVersionAnnotation = "v2.edp.epam.com/edp-version"
+	// IntegrationStrategiesAnnotation kept v1alpha1 spec.edpSpec.integrationStrategies on a v1 object before
+	// v1 got spec.features. It is only read from the objects stored back then.
+	IntegrationStrategiesAnnotation = "v2.edp.epam.com/integration-strategies"
+	// V1SpecAnnotation keeps the whole v1 spec on a v1alpha1 object.
+	V1SpecAnnotation = "v2.edp.epam.com/v1-spec"
+	// V1StatusAnnotation keeps the v1 only status fields on a v1alpha1 object.
+	V1StatusAnnotation = "v2.edp.epam.com/v1-status"
+)
+
+// v1Status holds the v1 status fields which have no v1alpha1 counterpart.
+type v1Status struct {
+	ObservedGeneration  int64              `json:"observedGeneration,omitempty"`
+	Conditions          []metav1.Condition `json:"conditions,omitempty"`
+	LastRotationTime    *metav1.Time       `json:"lastRotationTime,omitempty"`
+	LastRotationRequest string             `json:"lastRotationRequest,omitempty"`
+}
+
+// ConvertTo converts this AdminConsole to the hub version (v1).
+func (in *AdminConsole) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*adminConsoleApiV1.AdminConsole)
+	if !ok {
+		return errors.Errorf("unexpected conversion hub type %T", dstRaw)
+	}
+
+	in.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	if raw, ok := in.Annotations[V1SpecAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &dst.Spec); err != nil {
+			return errors.Wrapf(err, "unable to restore v1 spec from %s annotation", V1SpecAnnotation)
+		}
+		delete(dst.Annotations, V1SpecAnnotation)
+	}
+
+	dst.Spec.Image = in.Spec.Image
+	dst.Spec.Version = in.Spec.Version
+	dst.Spec.ImagePullSecrets = in.Spec.ImagePullSecrets
+	dst.Spec.KeycloakSpec.Enabled = in.Spec.KeycloakSpec.Enabled
+	dst.Spec.EdpSpec.Name = in.Spec.EdpSpec.Name
+	dst.Spec.EdpSpec.DnsWildcard = in.Spec.EdpSpec.DnsWildcard
+	dst.Spec.EdpSpec.TestReportTools = in.Spec.EdpSpec.TestReportTools
+	dst.Spec.DbSpec.Name = in.Spec.DbSpec.Name
+	dst.Spec.DbSpec.Hostname = in.Spec.DbSpec.Hostname
+	dst.Spec.DbSpec.Port = in.Spec.DbSpec.Port
+	dst.Spec.DbSpec.Enabled = in.Spec.DbSpec.Enabled
+	dst.Spec.BasePath = in.Spec.BasePath
+
+	strategies := splitList(in.Spec.EdpSpec.IntegrationStrategies)
+	if len(strategies) != 0 && dst.Spec.Features == nil {
+		dst.Spec.Features = &adminConsoleApiV1.FeaturesSpec{}
+	}
+	if dst.Spec.Features != nil {
+		dst.Spec.Features.IntegrationStrategies = strategies
+	}
+
+	setAnnotation(&dst.Annotations, EdpVersionAnnotation, in.Spec.EdpSpec.Version)
+	delete(dst.Annotations, IntegrationStrategiesAnnotation)
+
+	if raw, ok := in.Annotations[V1StatusAnnotation]; ok {
+		status := v1Status{}
+		if err := json.Unmarshal([]byte(raw), &status); err != nil {
+			return errors.Wrapf(err, "unable to restore v1 status from %s annotation", V1StatusAnnotation)
+		}
+		dst.Status.ObservedGeneration = status.ObservedGeneration
+		dst.Status.Conditions = status.Conditions
+		dst.Status.LastRotationTime = status.LastRotationTime
+		dst.Status.LastRotationRequest = status.LastRotationRequest
+		delete(dst.Annotations, V1StatusAnnotation)
+	}
+
+	dst.Status.Available = in.Status.Available
+	dst.Status.LastTimeUpdated = in.Status.LastTimeUpdated
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1) to this version.
+func (in *AdminConsole) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*adminConsoleApiV1.AdminConsole)
+	if !ok {
+		return errors.Errorf("unexpected conversion hub type %T", srcRaw)
+	}
+
+	src.ObjectMeta.DeepCopyInto(&in.ObjectMeta)
+
+	spec, err := json.Marshal(src.Spec)
+	if err != nil {
+		return errors.Wrap(err, "unable to store v1 spec")
+	}
+	setAnnotation(&in.Annotations, V1SpecAnnotation, string(spec))
+
+	in.Spec.Image = src.Spec.Image
+	in.Spec.Version = src.Spec.Version
+	in.Spec.ImagePullSecrets = src.Spec.ImagePullSecrets
+	in.Spec.KeycloakSpec.Enabled = src.Spec.KeycloakSpec.Enabled
+	in.Spec.EdpSpec = EdpSpec{
+		Version:               in.Annotations[EdpVersionAnnotation],
+		Name:                  src.Spec.EdpSpec.Name,
+		DnsWildcard:           src.Spec.EdpSpec.DnsWildcard,
+		IntegrationStrategies: in.Annotations[IntegrationStrategiesAnnotation],
+		TestReportTools:       src.Spec.EdpSpec.TestReportTools,
+	}
+	if f := src.Spec.Features; f != nil && len(f.IntegrationStrategies) != 0 {
+		in.Spec.EdpSpec.IntegrationStrategies = strings.Join(f.IntegrationStrategies, ",")
+	}
+	in.Spec.DbSpec = AdminConsoleDbSettings{
+		Name:     src.Spec.DbSpec.Name,
+		Hostname: src.Spec.DbSpec.Hostname,
+		Port:     src.Spec.DbSpec.Port,
+		Enabled:  src.Spec.DbSpec.Enabled,
+	}
+	in.Spec.BasePath = src.Spec.BasePath
+	delete(in.Annotations, EdpVersionAnnotation)
+	delete(in.Annotations, IntegrationStrategiesAnnotation)
+
+	in.Status.Available = src.Status.Available
+	in.Status.LastTimeUpdated = src.Status.LastTimeUpdated
+	in.Status.Status = ""
+	if ready := meta.FindStatusCondition(src.Status.Conditions, adminConsoleApiV1.ConditionReady); ready != nil {
+		in.Status.Status = ready.Reason
+	}
+
+	status, err := json.Marshal(v1Status{
+		ObservedGeneration:  src.Status.ObservedGeneration,
+		Conditions:          src.Status.Conditions,
+		LastRotationTime:    src.Status.LastRotationTime,
+		LastRotationRequest: src.Status.LastRotationRequest,
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to store v1 status")
+	}
+	setAnnotation(&in.Annotations, V1StatusAnnotation, string(status))
+
+	return nil
+}
+
+// splitList splits a comma-separated v1alpha1 list, e.g. "Create, Clone,Import".
+func splitList(list string) []string {
+	var out []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func setAnnotation(annotations *map[string]string, key, value string) {
+	if value == "" {
+		delete(*annotations, key)
+		return
+	}
+
+	if *annotations == nil {
+		*annotations = map[string]string{}
+	}
+	(*annotations)[key] = value
+}
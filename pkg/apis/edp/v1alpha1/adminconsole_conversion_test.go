@@ -0,0 +1,127 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApiV1 "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+func v1AdminConsole() *adminConsoleApiV1.AdminConsole {
+	replicas := int32(2)
+	return &adminConsoleApiV1.AdminConsole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "edp-admin-console",
+			Namespace:   "edp",
+			Annotations: map[string]string{"custom": "value", EdpVersionAnnotation: "2.12.0"},
+		},
+		Spec: adminConsoleApiV1.AdminConsoleSpec{
+			Image:            "epamedp/edp-admin-console",
+			Version:          "2.12.0",
+			ImagePullSecrets: []coreV1Api.LocalObjectReference{{Name: "regcred"}},
+			Replicas:         &replicas,
+			Port:             8080,
+			Env:              []coreV1Api.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+			Exposure:         adminConsoleApiV1.ExposureIngress,
+			KeycloakSpec: adminConsoleApiV1.KeycloakSpec{
+				Enabled:    true,
+				RealmRoles: []string{"developer"},
+			},
+			EdpSpec: adminConsoleApiV1.EdpSpec{
+				Name:            "edp",
+				DnsWildcard:     "example.com",
+				TestReportTools: adminConsoleApiV1.TestReportToolsAllure,
+			},
+			DbSpec: adminConsoleApiV1.AdminConsoleDbSettings{
+				Name:                 "edp-db",
+				Hostname:             "edp-db",
+				Port:                 "5432",
+				Enabled:              true,
+				CredentialsSecretRef: &adminConsoleApiV1.DbCredentialsSecretRef{Name: "db-admin-console"},
+			},
+			BasePath:     "/admin",
+			ConfigSource: adminConsoleApiV1.ConfigSourceConfigMap,
+			Features: &adminConsoleApiV1.FeaturesSpec{
+				IntegrationStrategies: []string{"Create", "Clone"},
+				BuildTools:            []string{"Maven"},
+			},
+		},
+		Status: adminConsoleApiV1.AdminConsoleStatus{
+			Available:           true,
+			LastTimeUpdated:     metav1.Unix(1654041600, 0),
+			LastRotationRequest: "1",
+			ObservedGeneration:  3,
+			Conditions: []metav1.Condition{{
+				Type:               adminConsoleApiV1.ConditionReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				LastTransitionTime: metav1.Unix(1654041600, 0),
+			}},
+		},
+	}
+}
+
+func TestAdminConsole_ConvertFromV1AndBack(t *testing.T) {
+	g := NewWithT(t)
+
+	src := v1AdminConsole()
+	alpha := &AdminConsole{}
+	g.Expect(alpha.ConvertFrom(src.DeepCopy())).Should(Succeed())
+	g.Expect(alpha.Annotations).Should(HaveKey(V1SpecAnnotation))
+	g.Expect(alpha.Spec.EdpSpec.Version).Should(Equal("2.12.0"))
+	g.Expect(alpha.Spec.EdpSpec.IntegrationStrategies).Should(Equal("Create,Clone"))
+	g.Expect(alpha.Status.Status).Should(Equal("Ready"))
+
+	dst := &adminConsoleApiV1.AdminConsole{}
+	g.Expect(alpha.ConvertTo(dst)).Should(Succeed())
+	g.Expect(dst).Should(Equal(src))
+}
+
+func TestAdminConsole_ConvertToV1AndBack(t *testing.T) {
+	g := NewWithT(t)
+
+	// v1 is the storage version, so every v1alpha1 object served carries the v1 spec and status annotations.
+	served := &AdminConsole{}
+	g.Expect(served.ConvertFrom(v1AdminConsole())).Should(Succeed())
+
+	src := served.DeepCopy()
+	src.Spec.Version = "2.13.0"
+	src.Spec.EdpSpec.IntegrationStrategies = "Import, Create"
+	src.Spec.DbSpec.Hostname = "edp-db-2"
+	src.Spec.KeycloakSpec.Enabled = false
+
+	hub := &adminConsoleApiV1.AdminConsole{}
+	g.Expect(src.ConvertTo(hub)).Should(Succeed())
+	g.Expect(hub.Annotations).ShouldNot(HaveKey(V1SpecAnnotation))
+	g.Expect(hub.Annotations).ShouldNot(HaveKey(V1StatusAnnotation))
+	g.Expect(hub.Spec.Version).Should(Equal("2.13.0"))
+	g.Expect(hub.Spec.Features.IntegrationStrategies).Should(Equal([]string{"Import", "Create"}))
+	g.Expect(hub.Spec.Features.BuildTools).Should(Equal([]string{"Maven"}))
+	g.Expect(hub.Spec.DbSpec.CredentialsSecretRef).ShouldNot(BeNil())
+	g.Expect(hub.Spec.KeycloakSpec.RealmRoles).Should(Equal([]string{"developer"}))
+	g.Expect(hub.Spec.ConfigSource).Should(Equal(adminConsoleApiV1.ConfigSourceConfigMap))
+	g.Expect(hub.Status.Conditions).Should(HaveLen(1))
+
+	dst := &AdminConsole{}
+	g.Expect(dst.ConvertFrom(hub)).Should(Succeed())
+	g.Expect(dst.Spec).Should(Equal(AdminConsoleSpec{
+		Image:            src.Spec.Image,
+		Version:          src.Spec.Version,
+		ImagePullSecrets: src.Spec.ImagePullSecrets,
+		KeycloakSpec:     src.Spec.KeycloakSpec,
+		EdpSpec: EdpSpec{
+			Version:               src.Spec.EdpSpec.Version,
+			Name:                  src.Spec.EdpSpec.Name,
+			DnsWildcard:           src.Spec.EdpSpec.DnsWildcard,
+			IntegrationStrategies: "Import,Create",
+			TestReportTools:       src.Spec.EdpSpec.TestReportTools,
+		},
+		DbSpec:   src.Spec.DbSpec,
+		BasePath: src.Spec.BasePath,
+	}))
+	g.Expect(dst.Status).Should(Equal(src.Status))
+	g.Expect(dst.Annotations).Should(HaveKeyWithValue("custom", "value"))
+}
@@ -0,0 +1,56 @@
+package keycloak
+
+import (
+	"context"
+
+	"github.com/Nerzal/gocloak/v10"
+	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
+	"github.com/pkg/errors"
+)
+
+// adminRealm is the realm the Keycloak operator logs in to with the admin credentials.
+const adminRealm = "master"
+
+// AdminCredentials are the credentials of the Keycloak admin from the Keycloak CR secret.
+type AdminCredentials struct {
+	Url      string
+	Username string
+	Password string
+	// AdminType is either user or serviceAccount, as in the Keycloak CR.
+	AdminType string
+}
+
+// SetClientSecret sets the secret of an existing confidential client. The Keycloak operator only
+// reads the client secret when it creates the client, so rotated secrets have to be pushed directly.
+func SetClientSecret(ctx context.Context, admin AdminCredentials, realm, clientId, secret string) error {
+	kc := gocloak.NewClient(admin.Url)
+
+	var (
+		token *gocloak.JWT
+		err   error
+	)
+	if admin.AdminType == keycloakV1Api.KeycloakAdminTypeServiceAccount {
+		token, err = kc.LoginClient(ctx, admin.Username, admin.Password, adminRealm)
+	} else {
+		token, err = kc.LoginAdmin(ctx, admin.Username, admin.Password, adminRealm)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "unable to log in to Keycloak %s", admin.Url)
+	}
+
+	clients, err := kc.GetClients(ctx, token.AccessToken, realm, gocloak.GetClientsParams{ClientID: &clientId})
+	if err != nil {
+		return errors.Wrapf(err, "unable to get client %s in realm %s", clientId, realm)
+	}
+	if len(clients) == 0 {
+		return errors.Errorf("client %s is not found in realm %s", clientId, realm)
+	}
+
+	client := *clients[0]
+	client.Secret = &secret
+	if err := kc.UpdateClient(ctx, token.AccessToken, realm, client); err != nil {
+		return errors.Wrapf(err, "unable to update secret of client %s in realm %s", clientId, realm)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const connectTimeout = 10 * time.Second
+
+// driverName is the database/sql driver the connections are opened with, the tests replace it with sqlmock.
+var driverName = "postgres"
+
+// ConnectionSettings describe how to reach the admin console database.
+type ConnectionSettings struct {
+	Host     string
+	Port     string
+	Database string
+	User     string
+	Password string
+	// SslMode is passed to lib/pq as is. When it is empty SSL is tried first
+	// and the check falls back to a plain connection, like the libpq "prefer" mode.
+	SslMode string
+	// CaCert is the PEM encoded CA certificate of the database server.
+	CaCert []byte
+}
+
+// CheckConnection connects to the database and pings it.
+func CheckConnection(ctx context.Context, settings ConnectionSettings) error {
+	_, cleanup, err := connect(ctx, settings)
+	if err != nil {
+		return err
+	}
+
+	cleanup()
+	return nil
+}
+
+// connect opens a connection pool and makes sure the database answers. The returned cleanup
+// function closes the pool and removes the temporary CA certificate file.
+func connect(ctx context.Context, settings ConnectionSettings) (*sql.DB, func(), error) {
+	caPath, removeCaCert, err := writeCaCert(settings.CaCert)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sslMode := settings.SslMode
+	if sslMode == "" {
+		sslMode = "require"
+	}
+
+	db, err := ping(ctx, settings.dataSourceName(sslMode, caPath))
+	if errors.Is(err, pq.ErrSSLNotSupported) && settings.SslMode == "" {
+		db, err = ping(ctx, settings.dataSourceName("disable", caPath))
+	}
+	if err != nil {
+		removeCaCert()
+		return nil, nil, errors.Wrapf(err, "unable to connect to database %s on %s:%s", settings.Database, settings.Host, settings.Port)
+	}
+
+	return db, func() {
+		_ = db.Close()
+		removeCaCert()
+	}, nil
+}
+
+func ping(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func (s ConnectionSettings) dataSourceName(sslMode, caPath string) string {
+	params := []string{
+		param("host", s.Host),
+		param("port", s.Port),
+		param("dbname", s.Database),
+		param("user", s.User),
+		param("password", s.Password),
+		param("sslmode", sslMode),
+		param("connect_timeout", fmt.Sprint(int(connectTimeout.Seconds()))),
+	}
+	if caPath != "" {
+		params = append(params, param("sslrootcert", caPath))
+	}
+	return strings.Join(params, " ")
+}
+
+func param(key, value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return fmt.Sprintf("%s='%s'", key, value)
+}
+
+// writeCaCert stores the CA certificate in a temporary file, lib/pq only reads it from disk.
+func writeCaCert(caCert []byte) (string, func(), error) {
+	if len(caCert) == 0 {
+		return "", func() {}, nil
+	}
+
+	f, err := ioutil.TempFile("", "db-ca-*.crt")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "unable to create database CA certificate file")
+	}
+	cleanup := func() { _ = os.Remove(f.Name()) }
+
+	if _, err := f.Write(caCert); err != nil {
+		_ = f.Close()
+		cleanup()
+		return "", nil, errors.Wrap(err, "unable to write database CA certificate file")
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, errors.Wrap(err, "unable to write database CA certificate file")
+	}
+
+	return f.Name(), cleanup, nil
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// maintenanceDatabase is the database the admin connects to in order to create the admin console database.
+const maintenanceDatabase = "postgres"
+
+// Provisioning describes the objects created for the admin console in the database server.
+type Provisioning struct {
+	Database       string
+	Schema         string
+	ReaderUser     string
+	ReaderPassword string
+}
+
+// provisionedQuery checks, as the reader, that it may use the schema, read all its tables and the tables
+// the admin creates there later.
+const provisionedQuery = `SELECT has_schema_privilege($1, 'USAGE')
+	AND NOT EXISTS (
+		SELECT 1 FROM pg_tables
+		WHERE schemaname = $1 AND NOT has_table_privilege(format('%I.%I', schemaname, tablename), 'SELECT'))
+	AND EXISTS (
+		SELECT 1 FROM pg_default_acl d JOIN pg_namespace n ON n.oid = d.defaclnamespace
+		WHERE n.nspname = $1 AND d.defaclobjtype = 'r' AND d.defaclrole = (SELECT oid FROM pg_roles WHERE rolname = $2)
+			AND aclcontains(d.defaclacl, makeaclitem(
+				(SELECT oid FROM pg_roles WHERE rolname = current_user), d.defaclrole, 'SELECT', false)))`
+
+// Provision creates the database and the schema if they are missing and makes sure the reader role
+// exists with the given password and may only read the schema tables. It is safe to run repeatedly:
+// while the reader connects with the password and has the grants, nothing is changed and the admin
+// does not connect at all. The admin settings must carry the credentials of a user allowed to create
+// databases and roles.
+func Provision(ctx context.Context, admin ConnectionSettings, p Provisioning) error {
+	if provisioned(ctx, admin, p) {
+		return nil
+	}
+
+	if err := createDatabase(ctx, admin, p.Database); err != nil {
+		return err
+	}
+
+	admin.Database = p.Database
+	db, cleanup, err := connect(ctx, admin)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := exec(ctx, db, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pq.QuoteIdentifier(p.Schema))); err != nil {
+		return errors.Wrapf(err, "unable to create schema %s", p.Schema)
+	}
+
+	if err := ensureRole(ctx, db, p.ReaderUser, p.ReaderPassword); err != nil {
+		return err
+	}
+
+	var (
+		database = pq.QuoteIdentifier(p.Database)
+		schema   = pq.QuoteIdentifier(p.Schema)
+		reader   = pq.QuoteIdentifier(p.ReaderUser)
+	)
+	grants := []string{
+		fmt.Sprintf("GRANT CONNECT ON DATABASE %s TO %s", database, reader),
+		fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s", schema, reader),
+		fmt.Sprintf("GRANT SELECT ON ALL TABLES IN SCHEMA %s TO %s", schema, reader),
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA %s GRANT SELECT ON TABLES TO %s", schema, reader),
+	}
+	for _, q := range grants {
+		if err := exec(ctx, db, q); err != nil {
+			return errors.Wrapf(err, "unable to grant read access on schema %s to %s", p.Schema, p.ReaderUser)
+		}
+	}
+
+	return nil
+}
+
+// provisioned reports whether the reader connects to the database with its password and has the grants.
+// Any failure, e.g. a wrong password or a missing schema, means the provisioning has to run.
+func provisioned(ctx context.Context, admin ConnectionSettings, p Provisioning) bool {
+	reader := admin
+	reader.Database, reader.User, reader.Password = p.Database, p.ReaderUser, p.ReaderPassword
+
+	db, cleanup, err := connect(ctx, reader)
+	if err != nil {
+		return false
+	}
+	defer cleanup()
+
+	var ok bool
+	if err := db.QueryRowContext(ctx, provisionedQuery, p.Schema, admin.User).Scan(&ok); err != nil {
+		return false
+	}
+	return ok
+}
+
+// SetRolePassword changes the password of an existing login role.
+func SetRolePassword(ctx context.Context, admin ConnectionSettings, role, password string) error {
+	admin.Database = maintenanceDatabase
+	db, cleanup, err := connect(ctx, admin)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	err = exec(ctx, db, fmt.Sprintf("ALTER ROLE %s WITH PASSWORD %s", pq.QuoteIdentifier(role), pq.QuoteLiteral(password)))
+	if err != nil {
+		return errors.Wrapf(err, "unable to change password of role %s", role)
+	}
+	return nil
+}
+
+func createDatabase(ctx context.Context, admin ConnectionSettings, name string) error {
+	admin.Database = maintenanceDatabase
+	db, cleanup, err := connect(ctx, admin)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	exists, err := queryExists(ctx, db, "SELECT 1 FROM pg_database WHERE datname = $1", name)
+	if err != nil {
+		return errors.Wrapf(err, "unable to check whether database %s exists", name)
+	}
+	if exists {
+		return nil
+	}
+
+	if err := exec(ctx, db, fmt.Sprintf("CREATE DATABASE %s", pq.QuoteIdentifier(name))); err != nil {
+		return errors.Wrapf(err, "unable to create database %s", name)
+	}
+	return nil
+}
+
+// ensureRole creates the login role or resets its password, so it always matches the generated secret.
+func ensureRole(ctx context.Context, db *sql.DB, name, password string) error {
+	exists, err := queryExists(ctx, db, "SELECT 1 FROM pg_roles WHERE rolname = $1", name)
+	if err != nil {
+		return errors.Wrapf(err, "unable to check whether role %s exists", name)
+	}
+
+	stmt := "CREATE ROLE %s WITH LOGIN PASSWORD %s"
+	if exists {
+		stmt = "ALTER ROLE %s WITH LOGIN PASSWORD %s"
+	}
+
+	if err := exec(ctx, db, fmt.Sprintf(stmt, pq.QuoteIdentifier(name), pq.QuoteLiteral(password))); err != nil {
+		return errors.Wrapf(err, "unable to set up role %s", name)
+	}
+	return nil
+}
+
+func queryExists(ctx context.Context, db *sql.DB, query string, args ...interface{}) (bool, error) {
+	var one int
+	err := db.QueryRowContext(ctx, query, args...).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func exec(ctx context.Context, db *sql.DB, query string) error {
+	_, err := db.ExecContext(ctx, query)
+	return err
+}
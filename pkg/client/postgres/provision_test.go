@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	driverName = "sqlmock"
+}
+
+// mockDatabase registers a mock for the connections Provision opens with the settings. Connections opened
+// with settings that have no mock fail, like a wrong password does.
+func mockDatabase(t *testing.T, settings ConnectionSettings) sqlmock.Sqlmock {
+	db, mock, err := sqlmock.NewWithDSN(settings.dataSourceName(settings.SslMode, ""),
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return mock
+}
+
+func TestProvision(t *testing.T) {
+	p := Provisioning{
+		Database:       `Edp"Db`,
+		Schema:         "edp",
+		ReaderUser:     `read"er`,
+		ReaderPassword: `pa'ss`,
+	}
+
+	// expectSchema expects the statements run in the admin console database once it exists.
+	expectSchema := func(mock sqlmock.Sqlmock, roleExists bool) {
+		mock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "edp"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		rows := sqlmock.NewRows([]string{"?column?"})
+		if roleExists {
+			rows.AddRow(1)
+		}
+		mock.ExpectQuery("SELECT 1 FROM pg_roles WHERE rolname = $1").WithArgs(p.ReaderUser).WillReturnRows(rows)
+		if roleExists {
+			mock.ExpectExec(`ALTER ROLE "read""er" WITH LOGIN PASSWORD 'pa''ss'`).WillReturnResult(sqlmock.NewResult(0, 0))
+		} else {
+			mock.ExpectExec(`CREATE ROLE "read""er" WITH LOGIN PASSWORD 'pa''ss'`).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+	}
+
+	grants := []string{
+		`GRANT CONNECT ON DATABASE "Edp""Db" TO "read""er"`,
+		`GRANT USAGE ON SCHEMA "edp" TO "read""er"`,
+		`GRANT SELECT ON ALL TABLES IN SCHEMA "edp" TO "read""er"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "edp" GRANT SELECT ON TABLES TO "read""er"`,
+	}
+
+	tests := []struct {
+		name    string
+		expect  func(maintenance, database, reader func() sqlmock.Sqlmock)
+		wantErr string
+	}{
+		{
+			name: "nothing exists",
+			expect: func(maintenance, database, _ func() sqlmock.Sqlmock) {
+				m := maintenance()
+				m.ExpectQuery("SELECT 1 FROM pg_database WHERE datname = $1").WithArgs(p.Database).
+					WillReturnRows(sqlmock.NewRows([]string{"?column?"}))
+				m.ExpectExec(`CREATE DATABASE "Edp""Db"`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				d := database()
+				expectSchema(d, false)
+				for _, q := range grants {
+					d.ExpectExec(q).WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+			},
+		},
+		{
+			name: "database and role exist",
+			expect: func(maintenance, database, _ func() sqlmock.Sqlmock) {
+				maintenance().ExpectQuery("SELECT 1 FROM pg_database WHERE datname = $1").WithArgs(p.Database).
+					WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+
+				d := database()
+				expectSchema(d, true)
+				for _, q := range grants {
+					d.ExpectExec(q).WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+			},
+		},
+		{
+			name: "already provisioned",
+			expect: func(_, _, reader func() sqlmock.Sqlmock) {
+				reader().ExpectQuery(provisionedQuery).WithArgs(p.Schema, "postgres").
+					WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(true))
+			},
+		},
+		{
+			name: "reader grants missing",
+			expect: func(maintenance, database, reader func() sqlmock.Sqlmock) {
+				reader().ExpectQuery(provisionedQuery).WithArgs(p.Schema, "postgres").
+					WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(false))
+				maintenance().ExpectQuery("SELECT 1 FROM pg_database WHERE datname = $1").WithArgs(p.Database).
+					WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+
+				d := database()
+				expectSchema(d, true)
+				for _, q := range grants {
+					d.ExpectExec(q).WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+			},
+		},
+		{
+			name: "grant fails",
+			expect: func(maintenance, database, _ func() sqlmock.Sqlmock) {
+				maintenance().ExpectQuery("SELECT 1 FROM pg_database WHERE datname = $1").WithArgs(p.Database).
+					WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+
+				d := database()
+				expectSchema(d, true)
+				d.ExpectExec(grants[0]).WillReturnResult(sqlmock.NewResult(0, 0))
+				d.ExpectExec(grants[1]).WillReturnError(errors.New("permission denied for schema edp"))
+			},
+			wantErr: `unable to grant read access on schema edp to read"er: permission denied for schema edp`,
+		},
+		{
+			name: "database creation fails",
+			expect: func(maintenance, _, _ func() sqlmock.Sqlmock) {
+				m := maintenance()
+				m.ExpectQuery("SELECT 1 FROM pg_database WHERE datname = $1").WithArgs(p.Database).
+					WillReturnRows(sqlmock.NewRows([]string{"?column?"}))
+				m.ExpectExec(`CREATE DATABASE "Edp""Db"`).WillReturnError(errors.New("permission denied to create database"))
+			},
+			wantErr: `unable to create database Edp"Db: permission denied to create database`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Every case connects to its own host, the mocks are registered by connection string.
+			admin := ConnectionSettings{Host: tt.name, Port: "5432", User: "postgres", Password: "admin", SslMode: "disable"}
+			var mocks []sqlmock.Sqlmock
+			mockWith := func(settings ConnectionSettings) func() sqlmock.Sqlmock {
+				return func() sqlmock.Sqlmock {
+					mock := mockDatabase(t, settings)
+					mocks = append(mocks, mock)
+					return mock
+				}
+			}
+
+			maintenance, database, reader := admin, admin, admin
+			maintenance.Database = maintenanceDatabase
+			database.Database = p.Database
+			reader.Database, reader.User, reader.Password = p.Database, p.ReaderUser, p.ReaderPassword
+			tt.expect(mockWith(maintenance), mockWith(database), mockWith(reader))
+
+			err := Provision(context.Background(), admin, p)
+			if tt.wantErr != "" {
+				g.Expect(err).Should(MatchError(tt.wantErr))
+			} else {
+				g.Expect(err).ShouldNot(HaveOccurred())
+			}
+
+			for _, mock := range mocks {
+				g.Expect(mock.ExpectationsWereMet()).Should(Succeed())
+			}
+		})
+	}
+}
+
+func TestSetRolePassword(t *testing.T) {
+	g := NewWithT(t)
+
+	admin := ConnectionSettings{Host: "set-role-password", Port: "5432", User: "postgres", Password: "admin", SslMode: "disable"}
+	maintenance := admin
+	maintenance.Database = maintenanceDatabase
+	mock := mockDatabase(t, maintenance)
+	mock.ExpectExec(`ALTER ROLE "read""er" WITH PASSWORD 'pa''ss'`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	g.Expect(SetRolePassword(context.Background(), admin, `read"er`, `pa'ss`)).Should(Succeed())
+	g.Expect(mock.ExpectationsWereMet()).Should(Succeed())
+}
@@ -3,61 +3,123 @@ package adminconsole
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/controller/helper"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/metrics"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/service/admin_console"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
+	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
 )
 
 const (
-	StatusInstall          = "installing"
-	StatusFailed           = "failed"
-	StatusCreated          = "created"
-	StatusExposeStart      = "exposing config"
-	StatusExposeFinish     = "config exposed"
-	StatusIntegrationStart = "integration started"
-	StatusReady            = "ready"
-	DefaultRequeueTime     = 30
+	DefaultRequeueTime = 30
+	CleanupRequeueTime = 5
+	// BaseRetryDelay is the delay of the first retry of a failed reconciliation, it doubles on every consecutive failure
+	BaseRetryDelay = time.Second
+	FinalizerName  = "admin.console.operator.finalizer.name"
 )
 
-func NewReconcileAdminConsole(client client.Client, scheme *runtime.Scheme, log logr.Logger) (*ReconcileAdminConsole, error) {
-	ps, err := platform.NewPlatformService(helper.GetPlatformTypeEnv(), scheme, &client)
+// stepConditions are the conditions which must all be True for the AdminConsole to be Ready. A step the operator
+// could not verify reports Unknown, it does not block readiness but is named in the Ready message.
+var stepConditions = []string{
+	adminConsoleApi.ConditionDeploymentReady,
+	adminConsoleApi.ConditionSecretsReady,
+	adminConsoleApi.ConditionKeycloakClientReady,
+	adminConsoleApi.ConditionEDPComponentPublished,
+	adminConsoleApi.ConditionDatabaseProvisioned,
+	adminConsoleApi.ConditionDatabaseReady,
+	adminConsoleApi.ConditionEnvPatched,
+}
+
+func NewReconcileAdminConsole(client client.Client, scheme *runtime.Scheme, log logr.Logger, recorder record.EventRecorder) (*ReconcileAdminConsole, error) {
+	callTimeout, err := helper.GetPlatformCallTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := platform.NewPlatformService(helper.GetPlatformTypeEnv(), scheme, &client, callTimeout)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create platform service")
 	}
 
+	return NewReconcileAdminConsoleWithPlatform(client, scheme, log, recorder, ps)
+}
+
+// NewReconcileAdminConsoleWithPlatform creates the reconciler on top of the given platform service,
+// e.g. an in-memory one in the integration tests.
+func NewReconcileAdminConsoleWithPlatform(client client.Client, scheme *runtime.Scheme, log logr.Logger,
+	recorder record.EventRecorder, ps platform.PlatformService) (*ReconcileAdminConsole, error) {
+	reconcileTimeout, err := helper.GetReconcileTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	maxRetryDelay, err := helper.GetMaxRetryDelay()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceSelector, err := helper.GetNamespaceSelector()
+	if err != nil {
+		return nil, err
+	}
+
 	return &ReconcileAdminConsole{
-		client:  client,
-		scheme:  scheme,
-		service: admin_console.NewAdminConsoleService(ps, client, scheme),
-		log:     log.WithName("admin-console"),
+		client:            client,
+		scheme:            scheme,
+		service:           admin_console.NewAdminConsoleService(ps, client),
+		log:               log.WithName("admin-console"),
+		recorder:          recorder,
+		namespaceSelector: namespaceSelector,
+		reconcileTimeout:  reconcileTimeout,
+		maxRetryDelay:     maxRetryDelay,
 	}, nil
 }
 
 type ReconcileAdminConsole struct {
-	client  client.Client
-	scheme  *runtime.Scheme
-	service admin_console.AdminConsoleService
-	log     logr.Logger
+	client   client.Client
+	scheme   *runtime.Scheme
+	service  admin_console.AdminConsoleService
+	log      logr.Logger
+	recorder record.EventRecorder
+	// namespaceSelector limits the namespaces AdminConsoles are managed in, all watched namespaces when nil
+	namespaceSelector labels.Selector
+	// reconcileTimeout bounds a single reconciliation, so a slow API server does not block the worker
+	reconcileTimeout time.Duration
+	// maxRetryDelay caps the exponential backoff between retries of a failing AdminConsole
+	maxRetryDelay time.Duration
 }
 
 func (r *ReconcileAdminConsole) SetupWithManager(mgr ctrl.Manager) error {
-	c, err := controller.New("adminconsole-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New("adminconsole-controller", mgr, controller.Options{
+		Reconciler:  r,
+		RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(BaseRetryDelay, r.maxRetryDelay),
+	})
 	if err != nil {
 		return err
 	}
@@ -68,14 +130,38 @@ func (r *ReconcileAdminConsole) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	// TODO(user): Modify this to be the types you create that are owned by the primary resource
-	// Watch for changes to secondary resource Pods and requeue the owner AdminConsole
-	err = c.Watch(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{
-		IsController: true,
-		OwnerType:    &adminConsoleApi.AdminConsole{},
-	})
-	if err != nil {
-		return err
+	// Watch for changes to the objects created for the AdminConsole, so readiness changes are picked up right away
+	for _, obj := range ownedObjects(mgr.GetRESTMapper()) {
+		err = c.Watch(&source.Kind{Type: obj}, &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &adminConsoleApi.AdminConsole{},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Watch for changes to the objects the AdminConsole depends on, but does not own
+	m := adminConsoleMapper{client: mgr.GetClient()}
+	watches := []struct {
+		obj     client.Object
+		mapFunc handler.MapFunc
+	}{
+		{&keycloakV1Api.KeycloakClient{}, m.keycloakClientRequests},
+		{&keycloakV1Api.KeycloakRealm{}, m.keycloakRequests},
+		{&keycloakV1Api.Keycloak{}, m.keycloakRequests},
+		{secretMetadata(), m.secretRequests},
+		{&edpCompApi.EDPComponent{}, m.edpComponentRequests},
+	}
+	for _, w := range watches {
+		if err = c.Watch(&source.Kind{Type: w.obj}, handler.EnqueueRequestsFromMapFunc(w.mapFunc)); err != nil {
+			return err
+		}
+	}
+
+	// Pick up the AdminConsoles of a namespace once its labels match the namespace selector
+	if r.namespaceSelector != nil {
+		return c.Watch(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(m.namespaceRequests))
 	}
 
 	return nil
@@ -85,120 +171,239 @@ func (r *ReconcileAdminConsole) Reconcile(ctx context.Context, request reconcile
 	log := r.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	log.Info("Reconciling AdminConsole")
 
+	ctx, cancel := context.WithTimeout(ctx, r.reconcileTimeout)
+	defer cancel()
+
 	instance := &adminConsoleApi.AdminConsole{}
 	if err := r.client.Get(ctx, request.NamespacedName, instance); err != nil {
 		if k8sErrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-			// Return and don't requeue
+			// Owned objects are automatically garbage collected. Objects which are not owned
+			// are removed by the finalizer. Return and don't requeue
+			metrics.Delete(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 
-	if instance.Status.Status == "" || instance.Status.Status == StatusFailed {
-		log.Info("Installation has been started")
-		if err := r.updateStatus(ctx, instance, StatusInstall); err != nil {
-			return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, err
-		}
+	if instance.GetDeletionTimestamp() != nil {
+		return r.cleanup(ctx, instance)
 	}
 
-	if instance.Status.Status == StatusInstall {
-		log.Info("Installation has finished")
-		if err := r.updateStatus(ctx, instance, StatusCreated); err != nil {
-			return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, err
-		}
+	managed, err := r.isNamespaceManaged(ctx, instance.Namespace)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !managed {
+		log.V(1).Info("Namespace does not match the namespace selector, skipping")
+		metrics.Delete(request.NamespacedName)
+		return reconcile.Result{}, nil
 	}
 
-	if dcIsReady, err := r.service.IsDeploymentReady(*instance); err != nil {
-		return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, errors.Wrapf(err, "Checking if Deployment configs is ready has been failed")
-	} else if !dcIsReady {
-		log.Info("Deployment config is not ready for exposing configuration yet")
-		return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, nil
+	if isStalled(instance) {
+		log.Info("AdminConsole is stalled, waiting for the spec to change")
+		return reconcile.Result{}, nil
 	}
+	meta.RemoveStatusCondition(&instance.Status.Conditions, adminConsoleApi.ConditionStalled)
 
-	if instance.Status.Status == StatusCreated {
-		log.Info("Exposing configuration has started")
-		if err := r.updateStatus(ctx, instance, StatusExposeStart); err != nil {
-			return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, err
+	if !controllerutil.ContainsFinalizer(instance, FinalizerName) {
+		controllerutil.AddFinalizer(instance, FinalizerName)
+		if err := r.client.Update(ctx, instance); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "unable to add finalizer")
 		}
 	}
 
-	instance, err := r.service.ExposeConfiguration(*instance)
+	if err := r.service.Install(ctx, *instance); err != nil {
+		metrics.IncStepFailure(request.NamespacedName, metrics.StepInstall)
+		instance.SetConditionFalse(adminConsoleApi.ConditionDeploymentReady, adminConsoleApi.ReasonDeploymentReconcileFailed, err.Error())
+		return r.failed(ctx, instance, errors.Wrap(err, "Installation failed"))
+	}
+
+	readiness, err := r.service.GetDeploymentReadiness(ctx, *instance)
 	if err != nil {
-		if err := r.updateStatus(ctx, instance, StatusFailed); err != nil {
-			return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, err
-		}
-		return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, errors.Wrapf(err, "Exposing configuration failed")
+		metrics.IncStepFailure(request.NamespacedName, metrics.StepDeploymentReadiness)
+		instance.SetConditionFalse(adminConsoleApi.ConditionDeploymentReady, adminConsoleApi.ReasonDeploymentCheckFailed, err.Error())
+		return r.failed(ctx, instance, errors.Wrap(err, "Checking if Deployment configs is ready has been failed"))
 	}
 
-	if instance.Status.Status == StatusExposeStart {
-		log.Info("Exposing configuration has finished")
-		err = r.updateStatus(ctx, instance, StatusExposeFinish)
-		if err != nil {
-			return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, err
+	if !readiness.Ready {
+		log.Info("Deployment config is not ready for exposing configuration yet", "reason", readiness.Message)
+		reason := adminConsoleApi.ReasonDeploymentNotReady
+		if readiness.Stuck {
+			metrics.IncStepFailure(request.NamespacedName, metrics.StepDeploymentReadiness)
+			reason = adminConsoleApi.ReasonProgressDeadlineExceeded
+		}
+		instance.SetConditionFalse(adminConsoleApi.ConditionDeploymentReady, reason, readiness.Message)
+		if err := r.updateStatus(ctx, instance); err != nil {
+			return reconcile.Result{}, err
 		}
+		// the rollout is not an error, the Deployment watch picks up its progress before the requeue
+		return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, nil
 	}
 
-	if instance.Status.Status == StatusExposeFinish {
-		log.Info("Integration has started")
-		err = r.updateStatus(ctx, instance, StatusIntegrationStart)
-		if err != nil {
-			return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, err
-		}
+	instance.SetConditionTrue(adminConsoleApi.ConditionDeploymentReady, adminConsoleApi.ReasonDeploymentAvailable, "Admin console pods are available")
+
+	instance, err = r.service.ExposeConfiguration(ctx, *instance)
+	if err != nil {
+		metrics.IncStepFailure(request.NamespacedName, metrics.StepExpose)
+		return r.failed(ctx, instance, errors.Wrap(err, "Exposing configuration failed"))
 	}
 
-	instance, err = r.service.Integrate(*instance)
+	instance, err = r.service.Integrate(ctx, *instance)
 	if err != nil {
-		log.Error(err, "couldn't finish integrating")
-		if err = r.updateStatus(ctx, instance, StatusFailed); err != nil {
-			return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, nil
-		}
-		return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, nil
+		metrics.IncStepFailure(request.NamespacedName, metrics.StepIntegrate)
+		return r.failed(ctx, instance, errors.Wrap(err, "Integration failed"))
 	}
 
-	if instance.Status.Status == StatusIntegrationStart {
-		log.Info("Exposing configuration has started")
-		err = r.updateStatus(ctx, instance, StatusReady)
-		if err != nil {
-			return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, err
-		}
+	instance, nextRotation, err := r.service.RotateCredentials(ctx, *instance)
+	if err != nil {
+		metrics.IncStepFailure(request.NamespacedName, metrics.StepRotate)
+		return r.failed(ctx, instance, errors.Wrap(err, "Credentials rotation failed"))
+	}
+
+	if err = r.updateStatus(ctx, instance); err != nil {
+		log.Info("Failed to update status")
+		return reconcile.Result{}, err
 	}
 
-	err = r.updateAvailableStatus(ctx, instance, true)
+	if instance.Status.Available {
+		metrics.SetReconcileSucceeded(request.NamespacedName)
+	}
+
+	// requeue for the next scheduled credentials rotation
+	return reconcile.Result{RequeueAfter: nextRotation}, nil
+}
+
+// cleanup runs the finalizer logic and releases the AdminConsole once the dependent objects are gone.
+func (r *ReconcileAdminConsole) cleanup(ctx context.Context, instance *adminConsoleApi.AdminConsole) (reconcile.Result, error) {
+	log := r.log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+
+	if !controllerutil.ContainsFinalizer(instance, FinalizerName) {
+		return reconcile.Result{}, nil
+	}
+
+	done, err := r.service.Cleanup(ctx, *instance)
 	if err != nil {
-		log.Info("Failed to update availability status")
-		return reconcile.Result{RequeueAfter: DefaultRequeueTime * time.Second}, err
+		return reconcile.Result{}, errors.Wrap(err, "Cleanup failed")
+	}
+
+	if !done {
+		log.Info("Waiting for dependent objects to be removed")
+		return reconcile.Result{RequeueAfter: CleanupRequeueTime * time.Second}, nil
 	}
 
+	controllerutil.RemoveFinalizer(instance, FinalizerName)
+	if err := r.client.Update(ctx, instance); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "unable to remove finalizer")
+	}
+
+	metrics.Delete(client.ObjectKeyFromObject(instance))
+	log.Info("Cleanup has finished", "deletionPolicy", instance.Spec.DeletionPolicy)
+	return reconcile.Result{}, nil
+}
+
+// failed writes the status of the failed step and decides how the reconciliation is retried. A transient error is
+// returned, so the AdminConsole is requeued with per-object exponential backoff. A permanent error parks the
+// AdminConsole with the Stalled condition until its spec changes.
+func (r *ReconcileAdminConsole) failed(ctx context.Context, instance *adminConsoleApi.AdminConsole, err error) (reconcile.Result, error) {
+	if !platformHelper.IsPermanent(err) {
+		if statusErr := r.updateStatus(ctx, instance); statusErr != nil {
+			r.log.Error(statusErr, "Failed to update status", "Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+		}
+		return reconcile.Result{}, err
+	}
+
+	instance.SetConditionTrue(adminConsoleApi.ConditionStalled, adminConsoleApi.ReasonPermanentError, err.Error())
+	if statusErr := r.updateStatus(ctx, instance); statusErr != nil {
+		return reconcile.Result{}, statusErr
+	}
+
+	r.log.Error(err, "AdminConsole is stalled until its spec changes", "Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileAdminConsole) updateStatus(ctx context.Context, instance *adminConsoleApi.AdminConsole, newStatus string) error {
+// isStalled reports whether a permanent error has been reported for the current generation of the AdminConsole.
+func isStalled(instance *adminConsoleApi.AdminConsole) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, adminConsoleApi.ConditionStalled)
+	return c != nil && c.Status == metav1.ConditionTrue && c.ObservedGeneration == instance.Generation
+}
+
+// isNamespaceManaged reports whether the labels of the namespace match the namespace selector.
+func (r *ReconcileAdminConsole) isNamespaceManaged(ctx context.Context, name string) (bool, error) {
+	if r.namespaceSelector == nil {
+		return true, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return false, errors.Wrapf(err, "unable to get namespace %s", name)
+	}
+	return r.namespaceSelector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// updateStatus computes the Ready condition from the step conditions and writes the status if it has changed.
+func (r *ReconcileAdminConsole) updateStatus(ctx context.Context, instance *adminConsoleApi.AdminConsole) error {
 	log := r.log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name).WithName("status_update")
-	currentStatus := instance.Status.Status
-	instance.Status.Status = newStatus
+
+	current := &adminConsoleApi.AdminConsole{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(instance), current); err != nil {
+		return errors.Wrap(err, "Couldn't get current status")
+	}
+
+	setReadyCondition(instance)
+	r.recordEvents(current.Status.Conditions, instance)
+	instance.Status.Available = instance.IsConditionTrue(adminConsoleApi.ConditionReady)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	ready := meta.FindStatusCondition(instance.Status.Conditions, adminConsoleApi.ConditionReady)
+	metrics.SetStatus(client.ObjectKeyFromObject(instance), ready.Reason, instance.Status.Available)
+
+	if equality.Semantic.DeepEqual(current.Status, instance.Status) {
+		return nil
+	}
+
 	instance.Status.LastTimeUpdated = metav1.Now()
+	instance.ResourceVersion = current.ResourceVersion
 
 	if err := r.client.Status().Update(ctx, instance); err != nil {
-		if err := r.client.Update(ctx, instance); err != nil {
-			return errors.Wrapf(err, "Couldn't update status from '%v' to '%v'", currentStatus, newStatus)
-		}
+		return errors.Wrap(err, "Couldn't update status")
 	}
 
-	log.Info(fmt.Sprintf("Status has been updated to '%v'", newStatus))
+	log.Info("Status has been updated", "ready", ready.Status, "reason", ready.Reason, "message", ready.Message)
 	return nil
 }
 
-func (r ReconcileAdminConsole) updateAvailableStatus(ctx context.Context, instance *adminConsoleApi.AdminConsole, value bool) error {
-	if instance.Status.Available != value {
-		instance.Status.Available = value
-		instance.Status.LastTimeUpdated = metav1.Now()
-		if err := r.client.Status().Update(ctx, instance); err != nil {
-			if err := r.client.Update(ctx, instance); err != nil {
-				return err
-			}
+// setReadyCondition marks the AdminConsole Ready when every step condition is True or Unknown,
+// otherwise the Ready condition points to the first step that is not done yet, or to the permanent error.
+func setReadyCondition(instance *adminConsoleApi.AdminConsole) {
+	if c := meta.FindStatusCondition(instance.Status.Conditions, adminConsoleApi.ConditionStalled); c != nil && c.Status == metav1.ConditionTrue {
+		instance.SetConditionFalse(adminConsoleApi.ConditionReady, c.Reason, c.Message)
+		return
+	}
+
+	var unverified []string
+	for _, t := range stepConditions {
+		c := meta.FindStatusCondition(instance.Status.Conditions, t)
+		if c == nil {
+			instance.SetConditionFalse(adminConsoleApi.ConditionReady, adminConsoleApi.ReasonReconcileInProgress,
+				fmt.Sprintf("%s has not been reported yet", t))
+			return
+		}
+
+		if c.Status == metav1.ConditionUnknown {
+			unverified = append(unverified, fmt.Sprintf("%s: %s", t, c.Message))
+			continue
+		}
+
+		if c.Status != metav1.ConditionTrue {
+			instance.SetConditionFalse(adminConsoleApi.ConditionReady, c.Reason, fmt.Sprintf("%s: %s", t, c.Message))
+			return
 		}
 	}
-	return nil
+
+	message := "Admin console is ready"
+	if len(unverified) != 0 {
+		message = fmt.Sprintf("%s, not verified: %s", message, strings.Join(unverified, "; "))
+	}
+	instance.SetConditionTrue(adminConsoleApi.ConditionReady, adminConsoleApi.ReasonReconcileSucceeded, message)
 }
@@ -0,0 +1,51 @@
+package adminconsole
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+// eventTypes are the condition reasons announced as events on the AdminConsole, with the type of the event.
+// Reasons of steps which are still in progress, e.g. a Deployment rolling out, are not announced.
+var eventTypes = map[string]string{
+	adminConsoleApi.ReasonSecretsCreated:               corev1.EventTypeNormal,
+	adminConsoleApi.ReasonKeycloakClientCreated:        corev1.EventTypeNormal,
+	adminConsoleApi.ReasonEnvPatched:                   corev1.EventTypeNormal,
+	adminConsoleApi.ReasonEDPComponentPublished:        corev1.EventTypeNormal,
+	adminConsoleApi.ReasonDatabaseProvisioned:          corev1.EventTypeNormal,
+	adminConsoleApi.ReasonCredentialsRotated:           corev1.EventTypeNormal,
+	adminConsoleApi.ReasonSecretsCreationFailed:        corev1.EventTypeWarning,
+	adminConsoleApi.ReasonKeycloakNotFound:             corev1.EventTypeWarning,
+	adminConsoleApi.ReasonKeycloakClientCreationFailed: corev1.EventTypeWarning,
+	adminConsoleApi.ReasonDbSettingsInvalid:            corev1.EventTypeWarning,
+	adminConsoleApi.ReasonDatabaseConnectionFailed:     corev1.EventTypeWarning,
+	adminConsoleApi.ReasonDatabaseProvisioningFailed:   corev1.EventTypeWarning,
+	adminConsoleApi.ReasonEnvPatchFailed:               corev1.EventTypeWarning,
+	adminConsoleApi.ReasonEnvConflict:                  corev1.EventTypeWarning,
+	adminConsoleApi.ReasonFeaturesInvalid:              corev1.EventTypeWarning,
+	adminConsoleApi.ReasonEDPComponentPublishFailed:    corev1.EventTypeWarning,
+	adminConsoleApi.ReasonEDPComponentsNotListed:       corev1.EventTypeWarning,
+	adminConsoleApi.ReasonProgressDeadlineExceeded:     corev1.EventTypeWarning,
+	adminConsoleApi.ReasonCredentialsRotationFailed:    corev1.EventTypeWarning,
+	adminConsoleApi.ReasonPermanentError:               corev1.EventTypeWarning,
+}
+
+// recordEvents emits an event for every condition which has changed its status or reason since the previous status,
+// so an event is recorded once per transition and not on every reconciliation.
+func (r *ReconcileAdminConsole) recordEvents(previous []metav1.Condition, instance *adminConsoleApi.AdminConsole) {
+	for _, c := range instance.Status.Conditions {
+		eventType, ok := eventTypes[c.Reason]
+		if !ok {
+			continue
+		}
+
+		if p := meta.FindStatusCondition(previous, c.Type); p != nil && p.Status == c.Status && p.Reason == c.Reason {
+			continue
+		}
+
+		r.recorder.Event(instance, eventType, c.Reason, c.Message)
+	}
+}
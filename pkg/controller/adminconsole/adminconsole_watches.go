@@ -0,0 +1,149 @@
+package adminconsole
+
+import (
+	"context"
+	"strings"
+
+	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
+	appsV1Api "github.com/openshift/api/apps/v1"
+	routeV1Api "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/controller/helper"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/openshift"
+)
+
+// ownedObjects returns the kinds of the objects the AdminConsole is the controller of on the current platform:
+// the generated secrets, the EDPComponent, the workload with its HorizontalPodAutoscaler and PodDisruptionBudget,
+// the Ingress or Route and the HTTPRoute when the Gateway API is installed. The Gateway API is looked up once,
+// an HTTPRoute CRD installed later is watched after a restart.
+func ownedObjects(mapper meta.RESTMapper) []client.Object {
+	objects := []client.Object{
+		secretMetadata(),
+		&corev1.ConfigMap{},
+		&edpCompApi.EDPComponent{},
+		&autoscalingv2beta2.HorizontalPodAutoscaler{},
+		&policyv1beta1.PodDisruptionBudget{},
+	}
+
+	gvk := platformHelper.HTTPRouteGVK
+	if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+		objects = append(objects, platformHelper.NewUnstructured(gvk, "", ""))
+	}
+
+	if strings.ToLower(helper.GetPlatformTypeEnv()) != platform.Openshift {
+		return append(objects, &appsv1.Deployment{}, &networkingv1.Ingress{})
+	}
+
+	objects = append(objects, &routeV1Api.Route{})
+	if openshift.UseDeploymentConfigs() {
+		return append(objects, &appsV1Api.DeploymentConfig{})
+	}
+	return append(objects, &appsv1.Deployment{})
+}
+
+// secretMetadata returns the object the secrets are watched with. Only their metadata is cached, which is all the
+// mappers look at, so the data of every secret in the watched namespaces is not held in memory; the platform
+// services read the secrets they need from the API server.
+func secretMetadata() *metav1.PartialObjectMetadata {
+	secret := &metav1.PartialObjectMetadata{}
+	secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	return secret
+}
+
+// adminConsoleMapper maps the objects the AdminConsole depends on, but does not own, to the AdminConsoles using them.
+type adminConsoleMapper struct {
+	client client.Client
+}
+
+// keycloakClientRequests enqueues the AdminConsole the KeycloakClient has been created for. The client is owned
+// by its KeycloakRealm, so it is matched by name.
+func (m adminConsoleMapper) keycloakClientRequests(obj client.Object) []reconcile.Request {
+	return m.requests(obj.GetNamespace(), func(ac adminConsoleApi.AdminConsole) bool {
+		return ac.Spec.KeycloakSpec.Enabled && ac.Name == obj.GetName()
+	})
+}
+
+// keycloakRequests enqueues the AdminConsoles with the Keycloak integration enabled when the KeycloakRealm
+// or the Keycloak in their namespace changes.
+func (m adminConsoleMapper) keycloakRequests(obj client.Object) []reconcile.Request {
+	return m.requests(obj.GetNamespace(), func(ac adminConsoleApi.AdminConsole) bool {
+		return ac.Spec.KeycloakSpec.Enabled
+	})
+}
+
+// edpComponentRequests enqueues the AdminConsoles in the namespace of the EDPComponent, so a changed URL
+// reaches their settings. The EDPComponent published for an AdminConsole is left to the owner watch.
+func (m adminConsoleMapper) edpComponentRequests(obj client.Object) []reconcile.Request {
+	return m.requests(obj.GetNamespace(), func(ac adminConsoleApi.AdminConsole) bool {
+		return ac.Name != obj.GetName()
+	})
+}
+
+// secretRequests enqueues the AdminConsoles whose database settings refer to the secret.
+func (m adminConsoleMapper) secretRequests(obj client.Object) []reconcile.Request {
+	return m.requests(obj.GetNamespace(), func(ac adminConsoleApi.AdminConsole) bool {
+		for _, name := range referencedSecrets(ac) {
+			if name == obj.GetName() {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// namespaceRequests enqueues all AdminConsoles in the namespace when its labels change.
+func (m adminConsoleMapper) namespaceRequests(obj client.Object) []reconcile.Request {
+	return m.requests(obj.GetName(), func(ac adminConsoleApi.AdminConsole) bool {
+		return true
+	})
+}
+
+func (m adminConsoleMapper) requests(namespace string, match func(ac adminConsoleApi.AdminConsole) bool) []reconcile.Request {
+	list := &adminConsoleApi.AdminConsoleList{}
+	if err := m.client.List(context.Background(), list, client.InNamespace(namespace)); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, ac := range list.Items {
+		if match(ac) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: ac.Namespace, Name: ac.Name},
+			})
+		}
+	}
+	return requests
+}
+
+// referencedSecrets returns the names of the user provided secrets the AdminConsole reads.
+func referencedSecrets(ac adminConsoleApi.AdminConsole) []string {
+	db := ac.Spec.DbSpec
+	if !db.Enabled {
+		return nil
+	}
+
+	var names []string
+	if db.CredentialsSecretRef != nil {
+		names = append(names, db.CredentialsSecretRef.Name)
+	}
+	if db.CaSecretRef != nil {
+		names = append(names, db.CaSecretRef.Name)
+	}
+	if db.Provisioning != nil {
+		names = append(names, db.Provisioning.AdminCredentialsSecretRef.Name)
+	}
+	return names
+}
@@ -0,0 +1,116 @@
+package adminconsole
+
+import (
+	"testing"
+
+	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
+	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+func TestAdminConsoleMapper(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := adminConsoleApi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	adminConsole := func(name, namespace string, spec adminConsoleApi.AdminConsoleSpec) *adminConsoleApi.AdminConsole {
+		return &adminConsoleApi.AdminConsole{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, Spec: spec}
+	}
+	database := func(credentials, ca, admin string) adminConsoleApi.AdminConsoleSpec {
+		return adminConsoleApi.AdminConsoleSpec{DbSpec: adminConsoleApi.AdminConsoleDbSettings{
+			Enabled:              true,
+			CredentialsSecretRef: &adminConsoleApi.DbCredentialsSecretRef{Name: credentials},
+			CaSecretRef:          &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: ca}},
+			Provisioning: &adminConsoleApi.DbProvisioning{
+				AdminCredentialsSecretRef: adminConsoleApi.DbCredentialsSecretRef{Name: admin},
+			},
+		}}
+	}
+	keycloakEnabled := adminConsoleApi.AdminConsoleSpec{KeycloakSpec: adminConsoleApi.KeycloakSpec{Enabled: true}}
+
+	m := adminConsoleMapper{client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		adminConsole("main", "edp", database("main-db", "db-ca", "postgres-admin")),
+		adminConsole("second", "edp", database("second-db", "db-ca", "postgres-admin")),
+		adminConsole("keycloak", "edp", keycloakEnabled),
+		adminConsole("disabled-db", "edp", adminConsoleApi.AdminConsoleSpec{DbSpec: adminConsoleApi.AdminConsoleDbSettings{
+			CredentialsSecretRef: &adminConsoleApi.DbCredentialsSecretRef{Name: "main-db"},
+		}}),
+		adminConsole("other", "other", database("main-db", "db-ca", "postgres-admin")),
+	).Build()}
+
+	secret := func(name string) client.Object {
+		s := secretMetadata()
+		s.Name, s.Namespace = name, "edp"
+		return s
+	}
+
+	tests := []struct {
+		name string
+		got  []reconcile.Request
+		want []string
+	}{
+		{name: "credentials secret of one admin console", got: m.secretRequests(secret("main-db")), want: []string{"main"}},
+		{name: "secret shared by admin consoles", got: m.secretRequests(secret("db-ca")), want: []string{"main", "second"}},
+		{name: "admin credentials secret", got: m.secretRequests(secret("postgres-admin")), want: []string{"main", "second"}},
+		{name: "unrelated secret", got: m.secretRequests(secret("admin-console-reader"))},
+		{
+			name: "keycloak client of an admin console",
+			got:  m.keycloakClientRequests(&keycloakV1Api.KeycloakClient{ObjectMeta: metav1.ObjectMeta{Name: "keycloak", Namespace: "edp"}}),
+			want: []string{"keycloak"},
+		},
+		{
+			name: "keycloak client of an admin console without keycloak",
+			got:  m.keycloakClientRequests(&keycloakV1Api.KeycloakClient{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "edp"}}),
+		},
+		{
+			name: "keycloak realm",
+			got:  m.keycloakRequests(&keycloakV1Api.KeycloakRealm{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "edp"}}),
+			want: []string{"keycloak"},
+		},
+		{
+			name: "edp component of another tool",
+			got:  m.edpComponentRequests(&edpCompApi.EDPComponent{ObjectMeta: metav1.ObjectMeta{Name: "sonar", Namespace: "other"}}),
+			want: []string{"other"},
+		},
+		{
+			name: "edp component published for an admin console",
+			got:  m.edpComponentRequests(&edpCompApi.EDPComponent{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "edp"}}),
+			want: []string{"disabled-db", "keycloak", "second"},
+		},
+		{
+			name: "namespace",
+			got:  m.namespaceRequests(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}),
+			want: []string{"other"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var names []string
+			for _, r := range tt.got {
+				names = append(names, r.Name)
+			}
+			g.Expect(names).Should(ConsistOf(tt.want))
+		})
+	}
+
+	t.Run("requests carry the namespace", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(m.namespaceRequests(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}})).Should(Equal([]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: "other", Name: "other"}},
+		}))
+	})
+}
@@ -2,28 +2,110 @@ package helper
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 )
 
 const (
-	watchNamespaceEnvVar          = "WATCH_NAMESPACE"
-	debugModeEnvVar               = "DEBUG_MODE"
-	inClusterNamespacePath        = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
-	platformType           string = "PLATFORM_TYPE"
+	watchNamespaceEnvVar             = "WATCH_NAMESPACE"
+	namespaceSelectorEnvVar          = "NAMESPACE_SELECTOR"
+	reconcileTimeoutEnvVar           = "RECONCILE_TIMEOUT"
+	platformCallTimeoutEnvVar        = "PLATFORM_CALL_TIMEOUT"
+	maxRetryDelayEnvVar              = "MAX_RETRY_DELAY"
+	debugModeEnvVar                  = "DEBUG_MODE"
+	enableWebhooksEnvVar             = "ENABLE_WEBHOOKS"
+	webhookServiceEnvVar             = "WEBHOOK_SERVICE_NAME"
+	inClusterNamespacePath           = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	platformType              string = "PLATFORM_TYPE"
+
+	defaultReconcileTimeout    = 5 * time.Minute
+	defaultPlatformCallTimeout = 30 * time.Second
+	defaultMaxRetryDelay       = 5 * time.Minute
 )
 
 func GetPlatformTypeEnv() string {
 	return os.Getenv(platformType)
 }
 
-// GetWatchNamespace returns the namespace the operator should be watching for changes
-func GetWatchNamespace() (string, error) {
-	ns, found := os.LookupEnv(watchNamespaceEnvVar)
+// GetWatchNamespaces returns the namespaces the operator should be watching for changes, a comma-separated list.
+// An empty list means all namespaces
+func GetWatchNamespaces() ([]string, error) {
+	value, found := os.LookupEnv(watchNamespaceEnvVar)
 	if !found {
-		return "", fmt.Errorf("%s must be set", watchNamespaceEnvVar)
+		return nil, fmt.Errorf("%s must be set", watchNamespaceEnvVar)
+	}
+
+	var namespaces []string
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, nil
+}
+
+// SetWatchNamespaces configures the manager cache to watch a single namespace, several namespaces
+// or, when the list is empty, the whole cluster
+func SetWatchNamespaces(options *ctrl.Options, namespaces []string) {
+	switch len(namespaces) {
+	case 0:
+		options.Namespace = ""
+	case 1:
+		options.Namespace = namespaces[0]
+	default:
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+}
+
+// GetNamespaceSelector returns the label selector of the namespaces the operator manages AdminConsoles in,
+// nil when all watched namespaces are managed
+func GetNamespaceSelector() (labels.Selector, error) {
+	value, found := os.LookupEnv(namespaceSelectorEnvVar)
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", namespaceSelectorEnvVar, err)
 	}
-	return ns, nil
+	return selector, nil
+}
+
+// GetReconcileTimeout returns the time a reconciliation of an AdminConsole may take
+func GetReconcileTimeout() (time.Duration, error) {
+	return getDuration(reconcileTimeoutEnvVar, defaultReconcileTimeout)
+}
+
+// GetPlatformCallTimeout returns the time a single call to the platform service may take
+func GetPlatformCallTimeout() (time.Duration, error) {
+	return getDuration(platformCallTimeoutEnvVar, defaultPlatformCallTimeout)
+}
+
+// GetMaxRetryDelay returns the cap of the exponential backoff between retries of a failed reconciliation
+func GetMaxRetryDelay() (time.Duration, error) {
+	return getDuration(maxRetryDelayEnvVar, defaultMaxRetryDelay)
+}
+
+func getDuration(envVar string, defaultValue time.Duration) (time.Duration, error) {
+	value, found := os.LookupEnv(envVar)
+	if !found || value == "" {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", envVar, err)
+	}
+	return d, nil
 }
 
 // GetDebugMode returns the debug mode value
@@ -40,6 +122,35 @@ func GetDebugMode() (bool, error) {
 	return b, nil
 }
 
+// GetWebhooksEnabled returns whether the admission webhooks should be served
+func GetWebhooksEnabled() (bool, error) {
+	enabled, found := os.LookupEnv(enableWebhooksEnvVar)
+	if !found {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(enabled)
+	if err != nil {
+		return false, err
+	}
+	return b, nil
+}
+
+// GetWebhookService returns the name and namespace of the service in front of the webhook server
+func GetWebhookService() (types.NamespacedName, error) {
+	name, found := os.LookupEnv(webhookServiceEnvVar)
+	if !found {
+		return types.NamespacedName{}, fmt.Errorf("%s must be set", webhookServiceEnvVar)
+	}
+
+	ns, err := ioutil.ReadFile(inClusterNamespacePath)
+	if err != nil {
+		return types.NamespacedName{}, fmt.Errorf("unable to read operator namespace: %w", err)
+	}
+
+	return types.NamespacedName{Name: name, Namespace: strings.TrimSpace(string(ns))}, nil
+}
+
 // Check whether the operator is running in cluster or locally
 func RunningInCluster() bool {
 	_, err := os.Stat(inClusterNamespacePath)
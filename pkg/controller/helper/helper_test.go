@@ -0,0 +1,117 @@
+package helper
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// setEnv sets or, when value is nil, unsets the variable for the duration of the test.
+func setEnv(t *testing.T, key string, value *string) {
+	old, found := os.LookupEnv(key)
+	t.Cleanup(func() {
+		if found {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+
+	if value == nil {
+		os.Unsetenv(key)
+		return
+	}
+	os.Setenv(key, *value)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestGetWatchNamespaces(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   *string
+		want    []string
+		wantErr bool
+	}{
+		{name: "not set", wantErr: true},
+		{name: "empty", value: stringPtr("")},
+		{name: "single", value: stringPtr("edp"), want: []string{"edp"}},
+		{name: "comma list", value: stringPtr(" edp, ,edp-delivery,"), want: []string{"edp", "edp-delivery"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			setEnv(t, watchNamespaceEnvVar, tt.value)
+
+			namespaces, err := GetWatchNamespaces()
+			if tt.wantErr {
+				g.Expect(err).Should(HaveOccurred())
+				return
+			}
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(namespaces).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetNamespaceSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   *string
+		want    string
+		wantErr bool
+	}{
+		{name: "not set"},
+		{name: "empty", value: stringPtr(" ")},
+		{name: "selector", value: stringPtr("edp.epam.com/managed=true"), want: "edp.epam.com/managed=true"},
+		{name: "invalid selector", value: stringPtr("edp.epam.com/managed in (true"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			setEnv(t, namespaceSelectorEnvVar, tt.value)
+
+			selector, err := GetNamespaceSelector()
+			if tt.wantErr {
+				g.Expect(err).Should(HaveOccurred())
+				return
+			}
+			g.Expect(err).ShouldNot(HaveOccurred())
+			if tt.want == "" {
+				g.Expect(selector).Should(BeNil())
+				return
+			}
+			g.Expect(selector.String()).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestSetWatchNamespaces(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespaces    []string
+		wantNamespace string
+		wantCache     bool
+	}{
+		{name: "cluster wide"},
+		{name: "single", namespaces: []string{"edp"}, wantNamespace: "edp"},
+		{name: "several", namespaces: []string{"edp", "edp-delivery"}, wantCache: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			options := ctrl.Options{}
+			SetWatchNamespaces(&options, tt.namespaces)
+			g.Expect(options.Namespace).Should(Equal(tt.wantNamespace))
+			g.Expect(options.NewCache != nil).Should(Equal(tt.wantCache))
+		})
+	}
+}
@@ -2,55 +2,172 @@ package helper
 
 import (
 	"context"
+	"fmt"
+
 	openshiftApi "github.com/openshift/api/apps/v1"
 	openshiftClient "github.com/openshift/client-go/apps/clientset/versioned/typed/apps/v1"
 	k8sApi "k8s.io/api/apps/v1"
+	coreV1Api "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sCLient "k8s.io/client-go/kubernetes/typed/apps/v1"
+	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
-func GetDeployment(client k8sCLient.AppsV1Client, name, namespace string) (*k8sApi.Deployment, error) {
+// progressDeadlineExceededReason is the reason of the Progressing condition of a Deployment or
+// a DeploymentConfig whose rollout has not made progress within its deadline.
+const progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+
+// Readiness describes the rollout state of the admin console workload.
+type Readiness struct {
+	Ready bool
+	// Stuck is true when the rollout has exceeded its progress deadline.
+	Stuck bool
+	// Message explains why the workload is not ready.
+	Message string
+}
+
+func GetDeployment(ctx context.Context, client k8sCLient.AppsV1Client, name, namespace string) (*k8sApi.Deployment, error) {
 	d, err := client.
 		Deployments(namespace).
-		Get(context.TODO(), name, metav1.GetOptions{})
+		Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
 	return d, nil
 }
 
-func IsDeploymentReady(client k8sCLient.AppsV1Client, name, namespace string) (bool, error) {
-	d, err := GetDeployment(client, name, namespace)
+// GetDeploymentReadiness checks the Deployment has rolled out its current generation to all desired replicas.
+// The reason of a failing pod is added to the message when the Deployment is not ready.
+func GetDeploymentReadiness(ctx context.Context, client k8sCLient.AppsV1Client, coreClient coreV1Client.CoreV1Interface, name, namespace string) (*Readiness, error) {
+	d, err := GetDeployment(ctx, client, name, namespace)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	stuck := false
+	for _, c := range d.Status.Conditions {
+		if c.Type == k8sApi.DeploymentProgressing && c.Reason == progressDeadlineExceededReason {
+			stuck = true
+		}
 	}
 
-	if d.Status.UpdatedReplicas == 1 && d.Status.AvailableReplicas == 1 {
-		return true, nil
+	r := rolloutReadiness(d.Generation, d.Status.ObservedGeneration, replicas, d.Status.Replicas,
+		d.Status.UpdatedReplicas, d.Status.AvailableReplicas, stuck)
+	if r.Ready {
+		return r, nil
 	}
 
-	return false, nil
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return withPodFailure(ctx, r, coreClient, namespace, selector)
 }
 
-func GetDeploymentConfig(client openshiftClient.AppsV1Client, name, namespace string) (*openshiftApi.DeploymentConfig, error) {
+func GetDeploymentConfig(ctx context.Context, client openshiftClient.AppsV1Client, name, namespace string) (*openshiftApi.DeploymentConfig, error) {
 	dc, err := client.
 		DeploymentConfigs(namespace).
-		Get(context.TODO(), name, metav1.GetOptions{})
+		Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
 	return dc, nil
 }
 
-func IsDeploymentConfigReady(client openshiftClient.AppsV1Client, name, namespace string) (bool, error) {
-	dc, err := GetDeploymentConfig(client, name, namespace)
+// GetDeploymentConfigReadiness checks the DeploymentConfig has rolled out its current generation to all desired replicas.
+// The reason of a failing pod is added to the message when the DeploymentConfig is not ready.
+func GetDeploymentConfigReadiness(ctx context.Context, client openshiftClient.AppsV1Client, coreClient coreV1Client.CoreV1Interface, name, namespace string) (*Readiness, error) {
+	dc, err := GetDeploymentConfig(ctx, client, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	stuck := false
+	for _, c := range dc.Status.Conditions {
+		if c.Type == openshiftApi.DeploymentProgressing && c.Reason == progressDeadlineExceededReason {
+			stuck = true
+		}
+	}
+
+	r := rolloutReadiness(dc.Generation, dc.Status.ObservedGeneration, dc.Spec.Replicas, dc.Status.Replicas,
+		dc.Status.UpdatedReplicas, dc.Status.AvailableReplicas, stuck)
+	if r.Ready {
+		return r, nil
+	}
+
+	return withPodFailure(ctx, r, coreClient, namespace, labels.SelectorFromSet(dc.Spec.Selector))
+}
+
+// rolloutReadiness follows the checks of kubectl rollout status: the controller has observed the current
+// generation, all desired replicas are updated, the old ones are gone and the updated ones are available.
+func rolloutReadiness(generation, observedGeneration int64, desired, replicas, updated, available int32, stuck bool) *Readiness {
+	r := &Readiness{Stuck: stuck}
+
+	switch {
+	case observedGeneration < generation:
+		r.Message = "Waiting for the rollout of the new spec to start"
+	case stuck:
+		r.Message = fmt.Sprintf("Rollout has exceeded its progress deadline, %d of %d replicas updated", updated, desired)
+	case updated < desired:
+		r.Message = fmt.Sprintf("Waiting for the rollout to finish, %d of %d replicas updated", updated, desired)
+	case replicas > updated:
+		r.Message = fmt.Sprintf("Waiting for the rollout to finish, %d old replicas are pending termination", replicas-updated)
+	case available < updated:
+		r.Message = fmt.Sprintf("Waiting for the rollout to finish, %d of %d updated replicas available", available, updated)
+	default:
+		r.Ready = true
+	}
+
+	return r
+}
+
+// withPodFailure adds the reason of the first failing pod selected by the selector to the readiness message.
+func withPodFailure(ctx context.Context, r *Readiness, coreClient coreV1Client.CoreV1Interface, namespace string, selector labels.Selector) (*Readiness, error) {
+	pods, err := coreClient.Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	if dc.Status.UpdatedReplicas == 1 && dc.Status.AvailableReplicas == 1 {
-		return true, nil
+	for _, pod := range pods.Items {
+		if failure := GetPodFailure(pod); failure != "" {
+			r.Message = fmt.Sprintf("%s; pod %s: %s", r.Message, pod.Name, failure)
+			break
+		}
 	}
+	return r, nil
+}
 
-	return false, nil
+// GetPodFailure returns the reason why the pod does not run, e.g. ImagePullBackOff or CrashLoopBackOff,
+// or an empty string when nothing is wrong with it.
+func GetPodFailure(pod coreV1Api.Pod) string {
+	statuses := append(append([]coreV1Api.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, s := range statuses {
+		if w := s.State.Waiting; w != nil && w.Reason != "" && w.Reason != "ContainerCreating" && w.Reason != "PodInitializing" {
+			return formatReason(w.Reason, w.Message)
+		}
+		if t := s.State.Terminated; t != nil && t.ExitCode != 0 {
+			return formatReason(t.Reason, t.Message)
+		}
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == coreV1Api.PodScheduled && c.Status == coreV1Api.ConditionFalse {
+			return formatReason(c.Reason, c.Message)
+		}
+	}
+
+	return ""
+}
+
+func formatReason(reason, message string) string {
+	if message == "" {
+		return reason
+	}
+	return fmt.Sprintf("%s: %s", reason, message)
 }
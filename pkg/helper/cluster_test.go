@@ -0,0 +1,134 @@
+package helper
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	coreV1Api "k8s.io/api/core/v1"
+)
+
+func TestRolloutReadiness(t *testing.T) {
+	tests := []struct {
+		name               string
+		generation         int64
+		observedGeneration int64
+		desired            int32
+		replicas           int32
+		updated            int32
+		available          int32
+		stuck              bool
+		wantReady          bool
+		wantMessage        string
+	}{
+		{
+			name:       "rolled out",
+			generation: 2, observedGeneration: 2,
+			desired: 2, replicas: 2, updated: 2, available: 2,
+			wantReady: true,
+		},
+		{
+			name:       "observed generation behind",
+			generation: 3, observedGeneration: 2,
+			desired: 2, replicas: 2, updated: 2, available: 2,
+			wantMessage: "Waiting for the rollout of the new spec to start",
+		},
+		{
+			name:       "stalled rollout",
+			generation: 2, observedGeneration: 2,
+			desired: 2, replicas: 3, updated: 1, available: 2,
+			stuck:       true,
+			wantMessage: "Rollout has exceeded its progress deadline, 1 of 2 replicas updated",
+		},
+		{
+			name:       "replicas not updated",
+			generation: 2, observedGeneration: 2,
+			desired: 2, replicas: 2, updated: 1, available: 2,
+			wantMessage: "Waiting for the rollout to finish, 1 of 2 replicas updated",
+		},
+		{
+			name:       "old replicas pending termination",
+			generation: 2, observedGeneration: 2,
+			desired: 2, replicas: 3, updated: 2, available: 2,
+			wantMessage: "Waiting for the rollout to finish, 1 old replicas are pending termination",
+		},
+		{
+			name:       "updated replicas not available",
+			generation: 2, observedGeneration: 2,
+			desired: 2, replicas: 2, updated: 2, available: 1,
+			wantMessage: "Waiting for the rollout to finish, 1 of 2 updated replicas available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := rolloutReadiness(tt.generation, tt.observedGeneration, tt.desired, tt.replicas, tt.updated, tt.available, tt.stuck)
+			g.Expect(r.Ready).Should(Equal(tt.wantReady))
+			g.Expect(r.Stuck).Should(Equal(tt.stuck))
+			g.Expect(r.Message).Should(Equal(tt.wantMessage))
+		})
+	}
+}
+
+func TestGetPodFailure(t *testing.T) {
+	waiting := func(reason, message string) coreV1Api.ContainerStatus {
+		return coreV1Api.ContainerStatus{State: coreV1Api.ContainerState{
+			Waiting: &coreV1Api.ContainerStateWaiting{Reason: reason, Message: message},
+		}}
+	}
+
+	tests := []struct {
+		name   string
+		status coreV1Api.PodStatus
+		want   string
+	}{
+		{
+			name: "running",
+			status: coreV1Api.PodStatus{ContainerStatuses: []coreV1Api.ContainerStatus{{
+				State: coreV1Api.ContainerState{Running: &coreV1Api.ContainerStateRunning{}},
+			}}},
+		},
+		{
+			name:   "container creating",
+			status: coreV1Api.PodStatus{ContainerStatuses: []coreV1Api.ContainerStatus{waiting("ContainerCreating", "")}},
+		},
+		{
+			name: "crash loop back off",
+			status: coreV1Api.PodStatus{ContainerStatuses: []coreV1Api.ContainerStatus{
+				waiting("CrashLoopBackOff", "back-off 5m0s restarting failed container"),
+			}},
+			want: "CrashLoopBackOff: back-off 5m0s restarting failed container",
+		},
+		{
+			name:   "image pull back off in init container",
+			status: coreV1Api.PodStatus{InitContainerStatuses: []coreV1Api.ContainerStatus{waiting("ImagePullBackOff", "")}},
+			want:   "ImagePullBackOff",
+		},
+		{
+			name: "terminated with error",
+			status: coreV1Api.PodStatus{ContainerStatuses: []coreV1Api.ContainerStatus{{
+				State: coreV1Api.ContainerState{Terminated: &coreV1Api.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+			}}},
+			want: "Error",
+		},
+		{
+			name: "unschedulable",
+			status: coreV1Api.PodStatus{Conditions: []coreV1Api.PodCondition{{
+				Type:    coreV1Api.PodScheduled,
+				Status:  coreV1Api.ConditionFalse,
+				Reason:  "Unschedulable",
+				Message: "0/3 nodes are available",
+			}}},
+			want: "Unschedulable: 0/3 nodes are available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(GetPodFailure(coreV1Api.Pod{Status: tt.status})).Should(Equal(tt.want))
+		})
+	}
+}
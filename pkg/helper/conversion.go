@@ -0,0 +1,58 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const conversionWebhookPath = "/convert"
+
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// PatchConversionWebhook points the conversion of the given CRD to the operator webhook service.
+// CRDs shipped in the chart crds/ directory are not templated, so the service namespace and CA bundle
+// are only known at runtime.
+func PatchConversionWebhook(ctx context.Context, c client.Client, crdName string, service types.NamespacedName, caBundle []byte) error {
+	path := conversionWebhookPath
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"conversion": map[string]interface{}{
+				"strategy": "Webhook",
+				"webhook": map[string]interface{}{
+					"conversionReviewVersions": []string{"v1", "v1beta1"},
+					"clientConfig": map[string]interface{}{
+						"caBundle": caBundle,
+						"service": map[string]interface{}{
+							"name":      service.Name,
+							"namespace": service.Namespace,
+							"path":      path,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal conversion patch")
+	}
+
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	crd.SetName(crdName)
+
+	if err := c.Patch(ctx, crd, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return errors.Wrapf(err, "unable to patch conversion webhook of %s CRD", crdName)
+	}
+
+	return nil
+}
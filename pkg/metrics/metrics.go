@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlMetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "admin_console"
+
+// Reconciliation steps failures are counted for.
+const (
+	StepInstall             = "install"
+	StepDeploymentReadiness = "deployment_readiness"
+	StepExpose              = "expose"
+	StepIntegrate           = "integrate"
+	StepRotate              = "rotate"
+)
+
+var (
+	phase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "phase",
+		Help:      "Current phase of the AdminConsole, the reason of its Ready condition. The series of the current phase is 1.",
+	}, []string{"namespace", "name", "phase"})
+
+	available = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "available",
+		Help:      "Whether the AdminConsole is available (1) or not (0).",
+	}, []string{"namespace", "name"})
+
+	lastSuccessfulReconcile = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_successful_reconcile_timestamp_seconds",
+		Help:      "Unix time of the last reconciliation which finished all steps of the AdminConsole.",
+	}, []string{"namespace", "name"})
+
+	stepFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "step_failures_total",
+		Help:      "Number of failed reconciliation steps of the AdminConsole.",
+	}, []string{"namespace", "name", "step"})
+
+	platformCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "platform_call_duration_seconds",
+		Help:      "Latency of the platform service calls.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "result"})
+)
+
+var (
+	phasesMu sync.Mutex
+	// phases holds the current phase of every AdminConsole, so the series of the previous phase can be removed.
+	phases = map[types.NamespacedName]string{}
+)
+
+func init() {
+	ctrlMetrics.Registry.MustRegister(phase, available, lastSuccessfulReconcile, stepFailures, platformCallDuration)
+}
+
+// SetStatus records the phase and the availability of the AdminConsole.
+func SetStatus(key types.NamespacedName, currentPhase string, isAvailable bool) {
+	phasesMu.Lock()
+	if previous, ok := phases[key]; ok && previous != currentPhase {
+		phase.DeleteLabelValues(key.Namespace, key.Name, previous)
+	}
+	phases[key] = currentPhase
+	phasesMu.Unlock()
+
+	phase.WithLabelValues(key.Namespace, key.Name, currentPhase).Set(1)
+	available.WithLabelValues(key.Namespace, key.Name).Set(boolToFloat(isAvailable))
+}
+
+// SetReconcileSucceeded records the time the AdminConsole has been reconciled successfully.
+func SetReconcileSucceeded(key types.NamespacedName) {
+	lastSuccessfulReconcile.WithLabelValues(key.Namespace, key.Name).SetToCurrentTime()
+}
+
+// IncStepFailure counts a failure of the reconciliation step of the AdminConsole.
+func IncStepFailure(key types.NamespacedName, step string) {
+	stepFailures.WithLabelValues(key.Namespace, key.Name, step).Inc()
+}
+
+// ObservePlatformCall records the duration of the platform service call.
+func ObservePlatformCall(method string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	platformCallDuration.WithLabelValues(method, result).Observe(duration.Seconds())
+}
+
+// Delete removes all series of the AdminConsole once it is gone.
+func Delete(key types.NamespacedName) {
+	phasesMu.Lock()
+	if previous, ok := phases[key]; ok {
+		phase.DeleteLabelValues(key.Namespace, key.Name, previous)
+		delete(phases, key)
+	}
+	phasesMu.Unlock()
+
+	available.DeleteLabelValues(key.Namespace, key.Name)
+	lastSuccessfulReconcile.DeleteLabelValues(key.Namespace, key.Name)
+	for _, step := range []string{StepInstall, StepDeploymentReadiness, StepExpose, StepIntegrate, StepRotate} {
+		stepFailures.DeleteLabelValues(key.Namespace, key.Name, step)
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
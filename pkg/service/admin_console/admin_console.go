@@ -2,20 +2,20 @@ package admin_console
 
 import (
 	"bufio"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/dchest/uniuri"
 	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
-	keycloakHelper "github.com/epam/edp-keycloak-operator/pkg/controller/helper"
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/runtime"
-	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/helper"
 	adminConsoleSpec "github.com/epam/edp-admin-console-operator/v2/pkg/service/admin_console/spec"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform"
 	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
@@ -27,89 +27,67 @@ const (
 )
 
 type AdminConsoleService interface {
-	ExposeConfiguration(instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error)
-	Integrate(instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error)
-	IsDeploymentReady(instance adminConsoleApi.AdminConsole) (bool, error)
+	Install(ctx context.Context, instance adminConsoleApi.AdminConsole) error
+	ExposeConfiguration(ctx context.Context, instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error)
+	Integrate(ctx context.Context, instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error)
+	GetDeploymentReadiness(ctx context.Context, instance adminConsoleApi.AdminConsole) (*helper.Readiness, error)
+	RotateCredentials(ctx context.Context, instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, time.Duration, error)
+	Cleanup(ctx context.Context, instance adminConsoleApi.AdminConsole) (bool, error)
 }
 
-func NewAdminConsoleService(ps platform.PlatformService, client client.Client, scheme *runtime.Scheme) AdminConsoleService {
+func NewAdminConsoleService(ps platform.PlatformService, client client.Client) AdminConsoleService {
+	return NewAdminConsoleServiceWithContributors(ps, client, NewContributors(ps, client))
+}
+
+// NewAdminConsoleServiceWithContributors creates the service running the given integrations, e.g. stubs in tests.
+func NewAdminConsoleServiceWithContributors(ps platform.PlatformService, client client.Client, contributors []IntegrationContributor) AdminConsoleService {
 	return AdminConsoleServiceImpl{
 		platformService: ps,
-		keycloakHelper:  keycloakHelper.MakeHelper(client, scheme, ctrl.Log.WithName("admin_console_service")),
+		client:          client,
+		contributors:    contributors,
 	}
 }
 
 type AdminConsoleServiceImpl struct {
 	// Providing sonar service implementation through the interface (platform abstract)
 	platformService platform.PlatformService
-	keycloakHelper  *keycloakHelper.Helper
+	client          client.Client
+	// contributors are the integrations run by Integrate, in order
+	contributors []IntegrationContributor
 }
 
-func (s AdminConsoleServiceImpl) Integrate(instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error) {
-
-	if instance.Spec.KeycloakSpec.Enabled {
-
-		keycloakClient, err := s.platformService.GetKeycloakClient(instance.Name, instance.Namespace)
-		if err != nil {
-			return &instance, errors.Wrap(err, "Failed to get Keycloak client data!")
-		}
-
-		keycloakRealm, err := s.keycloakHelper.GetOwnerKeycloakRealm(keycloakClient.ObjectMeta)
-		if err != nil {
-			return &instance, errors.Wrap(err, "unable to get keycloak realm cr")
-		}
-
-		if keycloakRealm == nil {
-			return &instance, errors.New("Keycloak CR is not created yet!")
-		}
-
-		keycloak, err := s.keycloakHelper.GetOwnerKeycloak(keycloakRealm.ObjectMeta)
-		if err != nil {
-			errMsg := fmt.Sprintf("Failed to get owner for %s/%s", keycloakClient.Namespace, keycloakClient.Name)
-			return &instance, errors.Wrap(err, errMsg)
-		}
-
-		if keycloak == nil {
-			return &instance, errors.New("Keycloak CR is not created yet!")
-		}
-
-		dbEnvironmentValue, err := s.platformService.GenerateDbSettings(instance)
-		if err != nil {
-			return &instance, errors.Wrap(err, "Failed to generate environment variables for shared database!")
-		}
-
-		discoveryUrl := fmt.Sprintf("%s/auth/realms/%s", keycloak.Spec.Url, keycloakRealm.Spec.RealmName)
-		keycloakEnvironmentValue, err := s.platformService.GenerateKeycloakSettings(instance, discoveryUrl)
-		if err != nil {
-			return &instance, errors.Wrap(err, "Failed to generate environment variables for Keycloack!")
-		}
+// Install creates or updates the admin console workload: the Deployment, the Service, the autoscaler and
+// the disruption budget, and the Ingress or Route.
+func (s AdminConsoleServiceImpl) Install(ctx context.Context, instance adminConsoleApi.AdminConsole) error {
+	if err := s.platformService.CreateOrUpdateDeployment(ctx, instance); err != nil {
+		return errors.Wrap(err, "Failed to create Admin Console deployment!")
+	}
 
-		adminConsoleEnvironment := append(dbEnvironmentValue, keycloakEnvironmentValue...)
+	if err := s.platformService.CreateOrUpdateService(ctx, instance); err != nil {
+		return errors.Wrap(err, "Failed to create Admin Console service!")
+	}
 
-		err = s.platformService.PatchDeploymentEnv(instance, adminConsoleEnvironment)
-		if err != nil {
-			return &instance, nil
-		}
+	if err := s.platformService.CreateOrUpdateAvailability(ctx, instance); err != nil {
+		return errors.Wrap(err, "Failed to configure Admin Console availability!")
+	}
 
-		result, err := s.platformService.UpdateAdminConsole(instance)
-		if err != nil {
-			return &instance, errors.Wrap(err, fmt.Sprintf("Failed to update Admin Console %s!", instance.Name))
-		}
-		return result, nil
+	if err := s.platformService.CreateOrUpdateExposure(ctx, instance); err != nil {
+		return errors.Wrap(err, "Failed to expose Admin Console!")
 	}
 
-	return &instance, nil
+	return nil
 }
 
-func (s AdminConsoleServiceImpl) ExposeConfiguration(instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error) {
+func (s AdminConsoleServiceImpl) ExposeConfiguration(ctx context.Context, instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error) {
 	adminConsoleReaderPassword := uniuri.New()
 	adminConsoleReaderCredentials := map[string][]byte{
 		"username": []byte("admin-console-reader"),
 		"password": []byte(adminConsoleReaderPassword),
 	}
 
-	err := s.platformService.CreateSecret(instance, "admin-console-reader", adminConsoleReaderCredentials)
+	err := s.platformService.CreateSecret(ctx, instance, adminConsoleSpec.ReaderSecretName, adminConsoleReaderCredentials)
 	if err != nil {
+		instance.SetConditionFalse(adminConsoleApi.ConditionSecretsReady, adminConsoleApi.ReasonSecretsCreationFailed, err.Error())
 		return &instance, errors.Wrap(err, "Failed to create credentials for Admin Console read user.")
 	}
 
@@ -117,50 +95,102 @@ func (s AdminConsoleServiceImpl) ExposeConfiguration(instance adminConsoleApi.Ad
 
 		adminConsoleClientPassword := uniuri.New()
 		adminConsoleClientCredentials := map[string][]byte{
-			"username":     []byte(adminConsoleSpec.DefaultKeycloakSecretName),
+			"username":     []byte(platformHelper.GetKeycloakClientId(instance)),
 			"password":     []byte(adminConsoleClientPassword),
 			"clientSecret": []byte(adminConsoleClientPassword),
 		}
 
-		err = s.platformService.CreateSecret(instance, adminConsoleSpec.DefaultKeycloakSecretName, adminConsoleClientCredentials)
+		err = s.platformService.CreateSecret(ctx, instance, adminConsoleSpec.DefaultKeycloakSecretName, adminConsoleClientCredentials)
 		if err != nil {
+			instance.SetConditionFalse(adminConsoleApi.ConditionSecretsReady, adminConsoleApi.ReasonSecretsCreationFailed, err.Error())
 			return &instance, errors.Wrap(err, "Failed to create secret")
 		}
 
-		u, err := s.platformService.GetExternalUrl(instance.Namespace, instance.Name)
+		// the client ID may change after the secret has been generated, KEYCLOAK_CLIENT_ID is read from it
+		err = s.platformService.PatchSecretData(ctx, instance.Namespace, adminConsoleSpec.DefaultKeycloakSecretName,
+			map[string][]byte{"username": []byte(platformHelper.GetKeycloakClientId(instance))})
+		if err != nil {
+			instance.SetConditionFalse(adminConsoleApi.ConditionSecretsReady, adminConsoleApi.ReasonSecretsCreationFailed, err.Error())
+			return &instance, errors.Wrap(err, "Failed to update Keycloak client ID in secret")
+		}
+	}
+
+	instance.SetConditionTrue(adminConsoleApi.ConditionSecretsReady, adminConsoleApi.ReasonSecretsCreated, "Admin Console secrets exist")
+
+	if instance.Spec.KeycloakSpec.Enabled {
+
+		u, err := s.platformService.GetExternalUrl(ctx, instance)
 		if err != nil {
-			return &instance, errors.Wrapf(err, "Failed to get Route %s!", instance.Name)
+			reason := adminConsoleApi.ReasonKeycloakClientCreationFailed
+			if platformHelper.IsExposureNotFound(err) {
+				reason = adminConsoleApi.ReasonExposureNotFound
+			}
+			instance.SetConditionFalse(adminConsoleApi.ConditionKeycloakClientReady, reason, err.Error())
+			return &instance, errors.Wrapf(err, "Failed to get Admin Console URL %s!", instance.Name)
 		}
 
-		keycloakClient := keycloakV1Api.KeycloakClient{}
-		keycloakClient.Name = instance.Name
-		keycloakClient.Namespace = instance.Namespace
-		keycloakClient.Spec.ClientId = adminConsoleSpec.DefaultKeycloakSecretName
-		keycloakClient.Spec.DirectAccess = true
-		keycloakClient.Spec.WebUrl = *u
-		keycloakClient.Spec.Secret = adminConsoleSpec.DefaultKeycloakSecretName
-		keycloakClient.Spec.ServiceAccount = &keycloakV1Api.ServiceAccount{Enabled: true,
-			RealmRoles: []string{"developer"}}
-		keycloakClient.Spec.DefaultClientScopes = []string{"edp"}
-
-		err = s.platformService.CreateKeycloakClient(&keycloakClient)
+		keycloakClient := newKeycloakClient(instance, u)
+
+		err = s.platformService.CreateOrUpdateKeycloakClient(ctx, instance, keycloakClient)
 		if err != nil {
+			instance.SetConditionFalse(adminConsoleApi.ConditionKeycloakClientReady, adminConsoleApi.ReasonKeycloakClientCreationFailed, err.Error())
 			return &instance, errors.Wrapf(err, "Failed to create Keycloak Client!")
 		}
 
+		instance.SetConditionTrue(adminConsoleApi.ConditionKeycloakClientReady, adminConsoleApi.ReasonKeycloakClientCreated,
+			fmt.Sprintf("KeycloakClient %s exists", keycloakClient.Name))
+	} else {
+		instance.SetConditionTrue(adminConsoleApi.ConditionKeycloakClientReady, adminConsoleApi.ReasonKeycloakDisabled, "Keycloak integration is disabled")
 	}
 
-	result, err := s.platformService.UpdateAdminConsole(instance)
+	result, err := s.platformService.UpdateAdminConsole(ctx, instance)
 	if err != nil {
 		return &instance, errors.Wrap(err, fmt.Sprintf("Failed to update Admin Console %s!", instance.Name))
 	}
 
-	err = s.createEDPComponent(instance)
-	return result, err
+	if err = s.createEDPComponent(ctx, *result); err != nil {
+		result.SetConditionFalse(adminConsoleApi.ConditionEDPComponentPublished, adminConsoleApi.ReasonEDPComponentPublishFailed, err.Error())
+		return result, err
+	}
+
+	result.SetConditionTrue(adminConsoleApi.ConditionEDPComponentPublished, adminConsoleApi.ReasonEDPComponentPublished,
+		fmt.Sprintf("EDPComponent %s exists", result.Name))
+	return result, nil
 }
 
-func (s AdminConsoleServiceImpl) createEDPComponent(ac adminConsoleApi.AdminConsole) error {
-	url, err := s.getUrl(ac)
+// newKeycloakClient builds the KeycloakClient of the admin console from the keycloakSpec.
+// Public clients get neither a secret nor a service account.
+func newKeycloakClient(instance adminConsoleApi.AdminConsole, webUrl string) *keycloakV1Api.KeycloakClient {
+	spec := instance.Spec.KeycloakSpec
+
+	keycloakClient := &keycloakV1Api.KeycloakClient{}
+	keycloakClient.Name = instance.Name
+	keycloakClient.Namespace = instance.Namespace
+	keycloakClient.Spec.ClientId = platformHelper.GetKeycloakClientId(instance)
+	keycloakClient.Spec.Public = spec.Public
+	keycloakClient.Spec.DirectAccess = platformHelper.GetKeycloakDirectAccess(instance)
+	keycloakClient.Spec.WebUrl = webUrl
+	keycloakClient.Spec.DefaultClientScopes = platformHelper.GetKeycloakDefaultClientScopes(instance)
+
+	if spec.Public {
+		return keycloakClient
+	}
+
+	keycloakClient.Spec.Secret = adminConsoleSpec.DefaultKeycloakSecretName
+	keycloakClient.Spec.ServiceAccount = &keycloakV1Api.ServiceAccount{
+		Enabled:    true,
+		RealmRoles: platformHelper.GetKeycloakRealmRoles(instance),
+	}
+	for _, r := range spec.ClientRoles {
+		keycloakClient.Spec.ServiceAccount.ClientRoles = append(keycloakClient.Spec.ServiceAccount.ClientRoles,
+			keycloakV1Api.ClientRole{ClientID: r.ClientId, Roles: r.Roles})
+	}
+
+	return keycloakClient
+}
+
+func (s AdminConsoleServiceImpl) createEDPComponent(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	url, err := s.platformService.GetExternalUrl(ctx, ac)
 	if err != nil {
 		return err
 	}
@@ -170,15 +200,7 @@ func (s AdminConsoleServiceImpl) createEDPComponent(ac adminConsoleApi.AdminCons
 		return err
 	}
 
-	return s.platformService.CreateEDPComponentIfNotExist(ac, *url, *icon)
-}
-
-func (s AdminConsoleServiceImpl) getUrl(ac adminConsoleApi.AdminConsole) (*string, error) {
-	u, err := s.platformService.GetExternalUrl(ac.Namespace, ac.Name)
-	if err != nil {
-		return nil, err
-	}
-	return u, nil
+	return s.platformService.CreateEDPComponentIfNotExist(ctx, ac, url, *icon)
 }
 
 func (j AdminConsoleServiceImpl) getIcon() (*string, error) {
@@ -201,6 +223,45 @@ func (j AdminConsoleServiceImpl) getIcon() (*string, error) {
 	return &encoded, nil
 }
 
-func (s AdminConsoleServiceImpl) IsDeploymentReady(instance adminConsoleApi.AdminConsole) (bool, error) {
-	return s.platformService.IsDeploymentReady(instance)
+func (s AdminConsoleServiceImpl) GetDeploymentReadiness(ctx context.Context, instance adminConsoleApi.AdminConsole) (*helper.Readiness, error) {
+	return s.platformService.GetDeploymentReadiness(ctx, instance)
+}
+
+// Cleanup removes the objects created by ExposeConfiguration in reverse order: the EDPComponent,
+// the KeycloakClient and then the generated secrets. It returns false while the Keycloak operator
+// is still removing the client from the realm. With the retain deletion policy the objects are
+// detached from the admin console instead and left in place.
+func (s AdminConsoleServiceImpl) Cleanup(ctx context.Context, instance adminConsoleApi.AdminConsole) (bool, error) {
+	secrets := []string{adminConsoleSpec.ReaderSecretName, adminConsoleSpec.DefaultKeycloakSecretName}
+
+	if instance.Spec.DeletionPolicy == adminConsoleApi.DeletionPolicyRetain {
+		if err := s.platformService.ReleaseEDPComponent(ctx, instance); err != nil {
+			return false, err
+		}
+
+		for _, name := range secrets {
+			if err := s.platformService.ReleaseSecret(ctx, instance, name); err != nil {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	if err := s.platformService.DeleteEDPComponent(ctx, instance); err != nil {
+		return false, err
+	}
+
+	deleted, err := s.platformService.DeleteKeycloakClient(ctx, instance.Name, instance.Namespace)
+	if err != nil || !deleted {
+		return false, err
+	}
+
+	for _, name := range secrets {
+		if err := s.platformService.DeleteSecret(ctx, instance, name); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
 }
@@ -0,0 +1,130 @@
+package admin_console
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	coreV1Api "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// Contribution is what an integration adds to the admin console.
+type Contribution struct {
+	// Env is applied to the admin console container, as env or through the ConfigMap of the generated settings.
+	Env []coreV1Api.EnvVar
+	// Secrets are created for the admin console by name, unless they exist.
+	Secrets map[string]map[string][]byte
+	// Conditions are set on the AdminConsole status, also when the integration fails.
+	Conditions []metav1.Condition
+}
+
+// IntegrationContributor integrates the admin console with another tool, e.g. Keycloak or the database.
+// Contributors run on every platform, they reach the cluster through the platform service and the controller client.
+type IntegrationContributor interface {
+	// Name identifies the integration in the status and in errors.
+	Name() string
+	// Contribute returns the contribution of the integration. A contribution returned together with an error
+	// carries the conditions describing the failure, the rest of it is ignored.
+	Contribute(ctx context.Context, instance adminConsoleApi.AdminConsole) (*Contribution, error)
+}
+
+// NewContributors returns the integrations of the admin console in the order Integrate runs them.
+func NewContributors(ps platform.PlatformService, client client.Client) []IntegrationContributor {
+	return []IntegrationContributor{
+		dbContributor{platformService: ps},
+		edpContributor{platformService: ps},
+		edpComponentsContributor{platformService: ps},
+		featuresContributor{},
+		keycloakContributor{platformService: ps, client: client},
+	}
+}
+
+// Integrate runs the integrations of the service in order and applies the env they contribute to the admin console.
+// The first failing integration stops the others, nothing is applied then.
+func (s AdminConsoleServiceImpl) Integrate(ctx context.Context, instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error) {
+	var (
+		env     []coreV1Api.EnvVar
+		applied []string
+	)
+
+	for _, c := range s.contributors {
+		contribution, err := c.Contribute(ctx, instance)
+		if contribution != nil {
+			for _, condition := range contribution.Conditions {
+				instance.SetCondition(condition.Type, condition.Status, condition.Reason, condition.Message)
+			}
+		}
+		if err != nil {
+			return &instance, errors.Wrapf(err, "%s integration failed", c.Name())
+		}
+		if contribution == nil {
+			continue
+		}
+
+		for name, data := range contribution.Secrets {
+			if err := s.platformService.CreateSecret(ctx, instance, name, data); err != nil {
+				instance.SetConditionFalse(adminConsoleApi.ConditionSecretsReady, adminConsoleApi.ReasonSecretsCreationFailed, err.Error())
+				return &instance, errors.Wrapf(err, "Failed to create secret %s of %s integration", name, c.Name())
+			}
+		}
+
+		if len(contribution.Env) != 0 {
+			env = append(env, contribution.Env...)
+			applied = append(applied, c.Name())
+		}
+	}
+
+	if err := s.applyConfig(ctx, instance, env); err != nil {
+		instance.SetConditionFalse(adminConsoleApi.ConditionEnvPatched, envPatchFailedReason(err), err.Error())
+		return &instance, errors.Wrap(err, "Failed to patch Admin Console deployment environment!")
+	}
+
+	instance.SetConditionTrue(adminConsoleApi.ConditionEnvPatched, adminConsoleApi.ReasonEnvPatched,
+		fmt.Sprintf("Settings of the %s integrations have been applied", strings.Join(applied, ", ")))
+	return &instance, nil
+}
+
+// applyConfig applies the generated env to the admin console container. With the configMap source the plain values
+// are rendered into the ConfigMap first, which is deleted once the container no longer references it otherwise.
+func (s AdminConsoleServiceImpl) applyConfig(ctx context.Context, instance adminConsoleApi.AdminConsole, env []coreV1Api.EnvVar) error {
+	config, data := platformHelper.GenerateWorkloadConfig(instance, env)
+	if !platformHelper.UseConfigMap(instance) {
+		if err := s.platformService.PatchDeploymentEnv(ctx, instance, config); err != nil {
+			return err
+		}
+		return s.platformService.DeleteConfigMap(ctx, instance)
+	}
+
+	if err := s.platformService.CreateOrUpdateConfigMap(ctx, instance, data); err != nil {
+		return err
+	}
+	return s.platformService.PatchDeploymentEnv(ctx, instance, config)
+}
+
+// envPatchFailedReason tells the env owned by another field manager apart from other failures to patch the env.
+func envPatchFailedReason(err error) string {
+	if k8sErrors.IsConflict(err) {
+		return adminConsoleApi.ReasonEnvConflict
+	}
+	return adminConsoleApi.ReasonEnvPatchFailed
+}
+
+func conditionTrue(conditionType, reason, message string) metav1.Condition {
+	return metav1.Condition{Type: conditionType, Status: metav1.ConditionTrue, Reason: reason, Message: message}
+}
+
+func conditionFalse(conditionType, reason, message string) metav1.Condition {
+	return metav1.Condition{Type: conditionType, Status: metav1.ConditionFalse, Reason: reason, Message: message}
+}
+
+func conditionUnknown(conditionType, reason, message string) metav1.Condition {
+	return metav1.Condition{Type: conditionType, Status: metav1.ConditionUnknown, Reason: reason, Message: message}
+}
@@ -0,0 +1,159 @@
+package admin_console
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/client/postgres"
+	adminConsoleSpec "github.com/epam/edp-admin-console-operator/v2/pkg/service/admin_console/spec"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// dbContributor provisions the admin console database when requested, checks the connection and contributes
+// the connection settings.
+type dbContributor struct {
+	platformService platform.PlatformService
+}
+
+func (c dbContributor) Name() string {
+	return "db"
+}
+
+func (c dbContributor) Contribute(ctx context.Context, instance adminConsoleApi.AdminConsole) (*Contribution, error) {
+	contribution := &Contribution{}
+
+	provisioned, err := c.provisionDatabase(ctx, instance)
+	contribution.Conditions = append(contribution.Conditions, provisioned)
+	if err != nil {
+		return contribution, err
+	}
+
+	ready, err := c.checkDatabase(ctx, instance)
+	contribution.Conditions = append(contribution.Conditions, ready)
+	if err != nil {
+		return contribution, err
+	}
+
+	contribution.Env, err = platformHelper.GenerateDbEnv(instance)
+	if err != nil {
+		contribution.Conditions = append(contribution.Conditions,
+			conditionFalse(adminConsoleApi.ConditionEnvPatched, adminConsoleApi.ReasonDbSettingsInvalid, err.Error()))
+		return contribution, errors.Wrap(err, "Failed to generate environment variables for shared database!")
+	}
+
+	return contribution, nil
+}
+
+// provisionDatabase creates the admin console database, the schema and the read-only role with the password
+// from the generated reader secret, when dbSpec.provisioning is set.
+func (c dbContributor) provisionDatabase(ctx context.Context, instance adminConsoleApi.AdminConsole) (metav1.Condition, error) {
+	db := instance.Spec.DbSpec
+	if !db.Enabled || db.Provisioning == nil {
+		return conditionTrue(adminConsoleApi.ConditionDatabaseProvisioned, adminConsoleApi.ReasonProvisioningDisabled,
+			"Database is not provisioned by the operator"), nil
+	}
+
+	admin, err := dbConnectionSettings(ctx, c.platformService, instance, db.Provisioning.AdminCredentialsSecretRef)
+	if err != nil {
+		return conditionFalse(adminConsoleApi.ConditionDatabaseProvisioned, adminConsoleApi.ReasonDbSettingsInvalid, err.Error()),
+			errors.Wrap(err, "Failed to get database admin connection settings!")
+	}
+
+	reader, err := c.platformService.GetSecretData(ctx, instance.Namespace, adminConsoleSpec.ReaderSecretName)
+	if err != nil {
+		return conditionFalse(adminConsoleApi.ConditionDatabaseProvisioned, adminConsoleApi.ReasonDbSettingsInvalid, err.Error()),
+			errors.Wrap(err, "Failed to get Admin Console read user credentials!")
+	}
+
+	schema := db.Provisioning.Schema
+	if schema == "" {
+		schema = platformHelper.GetEdpName(instance)
+	}
+
+	err = postgres.Provision(ctx, *admin, postgres.Provisioning{
+		Database:       db.Name,
+		Schema:         schema,
+		ReaderUser:     string(reader["username"]),
+		ReaderPassword: string(reader["password"]),
+	})
+	if err != nil {
+		return conditionFalse(adminConsoleApi.ConditionDatabaseProvisioned, adminConsoleApi.ReasonDatabaseProvisioningFailed, err.Error()),
+			errors.Wrap(err, "Database provisioning failed!")
+	}
+
+	return conditionTrue(adminConsoleApi.ConditionDatabaseProvisioned, adminConsoleApi.ReasonDatabaseProvisioned,
+		fmt.Sprintf("Database %s, schema %s and role %s exist", db.Name, schema, reader["username"])), nil
+}
+
+// checkDatabase verifies the admin console database is reachable with the configured credentials before
+// they are patched into the deployment, so a wrong setting is reported in the status instead of failing pods.
+// Without credentials the connection cannot be checked, which is reported as Unknown.
+func (c dbContributor) checkDatabase(ctx context.Context, instance adminConsoleApi.AdminConsole) (metav1.Condition, error) {
+	db := instance.Spec.DbSpec
+	if !db.Enabled {
+		return conditionTrue(adminConsoleApi.ConditionDatabaseReady, adminConsoleApi.ReasonDatabaseDisabled, "Database is disabled"), nil
+	}
+
+	if db.CredentialsSecretRef == nil {
+		return conditionUnknown(adminConsoleApi.ConditionDatabaseReady, adminConsoleApi.ReasonDbCredentialsNotSet,
+			"Connection is not checked, dbSpec.credentialsSecretRef is not set"), nil
+	}
+
+	settings, err := dbConnectionSettings(ctx, c.platformService, instance, *db.CredentialsSecretRef)
+	if err != nil {
+		return conditionFalse(adminConsoleApi.ConditionDatabaseReady, adminConsoleApi.ReasonDbSettingsInvalid, err.Error()),
+			errors.Wrap(err, "Failed to get database connection settings!")
+	}
+
+	if err := postgres.CheckConnection(ctx, *settings); err != nil {
+		return conditionFalse(adminConsoleApi.ConditionDatabaseReady, adminConsoleApi.ReasonDatabaseConnectionFailed, err.Error()),
+			errors.Wrap(err, "Database connection check failed!")
+	}
+
+	return conditionTrue(adminConsoleApi.ConditionDatabaseReady, adminConsoleApi.ReasonDatabaseConnected,
+		fmt.Sprintf("Connected to database %s on %s:%s", db.Name, db.Hostname, db.Port)), nil
+}
+
+// dbConnectionSettings returns the settings to connect to the admin console database as the user from the referenced secret.
+func dbConnectionSettings(ctx context.Context, ps platform.PlatformService, instance adminConsoleApi.AdminConsole,
+	ref adminConsoleApi.DbCredentialsSecretRef) (*postgres.ConnectionSettings, error) {
+	db := instance.Spec.DbSpec
+
+	credentials, err := ps.GetSecretData(ctx, instance.Namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	usernameKey, passwordKey := platformHelper.GetDbCredentialsKeys(&ref)
+	settings := &postgres.ConnectionSettings{
+		Host:     db.Hostname,
+		Port:     db.Port,
+		Database: db.Name,
+		User:     string(credentials[usernameKey]),
+		Password: string(credentials[passwordKey]),
+		SslMode:  db.SslMode,
+	}
+	if settings.User == "" {
+		return nil, errors.Errorf("key %s is missing in secret %s", usernameKey, ref.Name)
+	}
+
+	if db.CaSecretRef != nil {
+		ca, err := ps.GetSecretData(ctx, instance.Namespace, db.CaSecretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		caKey := platformHelper.GetDbCaKey(instance)
+		if len(ca[caKey]) == 0 {
+			return nil, errors.Errorf("key %s is missing in secret %s", caKey, db.CaSecretRef.Name)
+		}
+		settings.CaCert = ca[caKey]
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,37 @@
+package admin_console
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// edpContributor contributes the external URL of the admin console, resolved from the object exposing it,
+// and the metadata of the EDP tenant the admin console belongs to.
+type edpContributor struct {
+	platformService platform.PlatformService
+}
+
+func (c edpContributor) Name() string {
+	return "edp"
+}
+
+func (c edpContributor) Contribute(ctx context.Context, instance adminConsoleApi.AdminConsole) (*Contribution, error) {
+	url, err := c.platformService.GetExternalUrl(ctx, instance)
+	if err != nil {
+		reason := adminConsoleApi.ReasonEnvPatchFailed
+		if platformHelper.IsExposureNotFound(err) {
+			reason = adminConsoleApi.ReasonExposureNotFound
+		}
+		return &Contribution{Conditions: []metav1.Condition{
+			conditionFalse(adminConsoleApi.ConditionEnvPatched, reason, err.Error()),
+		}}, errors.Wrapf(err, "Failed to get Admin Console URL %s!", instance.Name)
+	}
+
+	return &Contribution{Env: platformHelper.GenerateEdpEnv(instance, url)}, nil
+}
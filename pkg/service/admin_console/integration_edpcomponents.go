@@ -0,0 +1,33 @@
+package admin_console
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// edpComponentsContributor contributes the URLs of the EDPComponents published in the namespace of the admin
+// console, e.g. Jenkins, Sonar or Nexus. The controller watches them, so a changed URL is applied right away.
+type edpComponentsContributor struct {
+	platformService platform.PlatformService
+}
+
+func (c edpComponentsContributor) Name() string {
+	return "edpcomponents"
+}
+
+func (c edpComponentsContributor) Contribute(ctx context.Context, instance adminConsoleApi.AdminConsole) (*Contribution, error) {
+	components, err := c.platformService.ListEDPComponents(ctx, instance.Namespace)
+	if err != nil {
+		return &Contribution{Conditions: []metav1.Condition{
+			conditionFalse(adminConsoleApi.ConditionEnvPatched, adminConsoleApi.ReasonEDPComponentsNotListed, err.Error()),
+		}}, errors.Wrap(err, "Failed to list EDP components!")
+	}
+
+	return &Contribution{Env: platformHelper.GenerateEDPComponentEnv(instance, components)}, nil
+}
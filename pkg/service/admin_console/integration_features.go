@@ -0,0 +1,29 @@
+package admin_console
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// featuresContributor contributes the feature lists of spec.features.
+type featuresContributor struct{}
+
+func (c featuresContributor) Name() string {
+	return "features"
+}
+
+func (c featuresContributor) Contribute(_ context.Context, instance adminConsoleApi.AdminConsole) (*Contribution, error) {
+	env, err := platformHelper.GenerateFeatureEnv(instance)
+	if err != nil {
+		return &Contribution{Conditions: []metav1.Condition{
+			conditionFalse(adminConsoleApi.ConditionEnvPatched, adminConsoleApi.ReasonFeaturesInvalid, err.Error()),
+		}}, errors.Wrap(err, "Failed to generate environment variables for Admin Console features!")
+	}
+
+	return &Contribution{Env: env}, nil
+}
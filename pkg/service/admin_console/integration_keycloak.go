@@ -0,0 +1,106 @@
+package admin_console
+
+import (
+	"context"
+	"fmt"
+
+	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// keycloakContributor contributes the settings the admin console authenticates with against the realm
+// of its KeycloakClient, or AUTH_KEYCLOAK_ENABLED=false when the Keycloak integration is disabled.
+type keycloakContributor struct {
+	platformService platform.PlatformService
+	client          client.Client
+}
+
+func (c keycloakContributor) Name() string {
+	return "keycloak"
+}
+
+func (c keycloakContributor) Contribute(ctx context.Context, instance adminConsoleApi.AdminConsole) (*Contribution, error) {
+	if !instance.Spec.KeycloakSpec.Enabled {
+		return &Contribution{Env: platformHelper.GenerateKeycloakEnv(instance, "")}, nil
+	}
+
+	notFound := func(message string) *Contribution {
+		return &Contribution{Conditions: []metav1.Condition{
+			conditionFalse(adminConsoleApi.ConditionKeycloakClientReady, adminConsoleApi.ReasonKeycloakNotFound, message),
+		}}
+	}
+
+	keycloakClient, err := c.platformService.GetKeycloakClient(ctx, instance.Name, instance.Namespace)
+	if err != nil {
+		return notFound(err.Error()), errors.Wrap(err, "Failed to get Keycloak client data!")
+	}
+
+	keycloakRealm, err := getOwnerKeycloakRealm(ctx, c.client, keycloakClient.ObjectMeta)
+	if err != nil {
+		return notFound(err.Error()), errors.Wrap(err, "unable to get keycloak realm cr")
+	}
+
+	if keycloakRealm == nil {
+		return notFound("KeycloakRealm CR is not created yet"), errors.New("KeycloakRealm CR is not created yet!")
+	}
+
+	keycloak, err := getOwnerKeycloak(ctx, c.client, keycloakRealm.ObjectMeta)
+	if err != nil {
+		errMsg := fmt.Sprintf("Failed to get owner for %s/%s", keycloakClient.Namespace, keycloakClient.Name)
+		return notFound(errMsg), errors.Wrap(err, errMsg)
+	}
+
+	if keycloak == nil {
+		return notFound("Keycloak CR is not created yet"), errors.New("Keycloak CR is not created yet!")
+	}
+
+	discoveryUrl := fmt.Sprintf("%s/auth/realms/%s", keycloak.Spec.Url, keycloakRealm.Spec.RealmName)
+	return &Contribution{Env: platformHelper.GenerateKeycloakEnv(instance, discoveryUrl)}, nil
+}
+
+// getOwnerKeycloakRealm returns the KeycloakRealm the Keycloak operator has linked the object to, or nil when
+// the object is not linked yet or the realm does not exist.
+func getOwnerKeycloakRealm(ctx context.Context, c client.Client, obj metav1.ObjectMeta) (*keycloakV1Api.KeycloakRealm, error) {
+	realm := &keycloakV1Api.KeycloakRealm{}
+	if found, err := getOwner(ctx, c, obj, "KeycloakRealm", realm); err != nil || !found {
+		return nil, err
+	}
+	return realm, nil
+}
+
+// getOwnerKeycloak returns the Keycloak the object belongs to, or nil when the object is not linked yet
+// or the Keycloak does not exist.
+func getOwnerKeycloak(ctx context.Context, c client.Client, obj metav1.ObjectMeta) (*keycloakV1Api.Keycloak, error) {
+	keycloak := &keycloakV1Api.Keycloak{}
+	if found, err := getOwner(ctx, c, obj, "Keycloak", keycloak); err != nil || !found {
+		return nil, err
+	}
+	return keycloak, nil
+}
+
+// getOwner reads the owner of the object with the given kind into owner.
+func getOwner(ctx context.Context, c client.Client, obj metav1.ObjectMeta, kind string, owner client.Object) (bool, error) {
+	for _, ref := range obj.OwnerReferences {
+		if ref.Kind != kind {
+			continue
+		}
+
+		err := c.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: ref.Name}, owner)
+		if k8sErrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get %s %s/%s", kind, obj.Namespace, ref.Name)
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,114 @@
+package admin_console
+
+import (
+	"context"
+	"testing"
+
+	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
+	. "github.com/onsi/gomega"
+	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	testHelper "github.com/epam/edp-admin-console-operator/v2/test/helper"
+)
+
+func TestKeycloakContributor_Contribute(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := keycloakV1Api.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	keycloak := &keycloakV1Api.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "edp"},
+		Spec:       keycloakV1Api.KeycloakSpec{Url: "https://keycloak.example.com"},
+	}
+	realm := &keycloakV1Api.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "edp", OwnerReferences: []metav1.OwnerReference{
+			{Kind: "Keycloak", Name: keycloak.Name},
+		}},
+		Spec: keycloakV1Api.KeycloakRealmSpec{RealmName: "edp"},
+	}
+	realmOwner := []metav1.OwnerReference{{Kind: "KeycloakRealm", Name: realm.Name}}
+
+	tests := []struct {
+		name          string
+		enabled       bool
+		clientOwners  []metav1.OwnerReference
+		objects       []client.Object
+		wantErr       bool
+		wantEnv       []coreV1Api.EnvVar
+		wantCondition string
+	}{
+		{
+			name:    "keycloak disabled",
+			wantEnv: []coreV1Api.EnvVar{{Name: "AUTH_KEYCLOAK_ENABLED", Value: "false"}},
+		},
+		{
+			name:         "keycloak enabled",
+			enabled:      true,
+			clientOwners: realmOwner,
+			objects:      []client.Object{keycloak, realm},
+			wantEnv: []coreV1Api.EnvVar{
+				{Name: "KEYCLOAK_URL", Value: "https://keycloak.example.com/auth/realms/edp"},
+				{Name: "AUTH_KEYCLOAK_ENABLED", Value: "true"},
+			},
+		},
+		{
+			name:          "realm not linked yet",
+			enabled:       true,
+			objects:       []client.Object{keycloak, realm},
+			wantErr:       true,
+			wantCondition: adminConsoleApi.ReasonKeycloakNotFound,
+		},
+		{
+			name:          "keycloak not created yet",
+			enabled:       true,
+			clientOwners:  realmOwner,
+			objects:       []client.Object{realm},
+			wantErr:       true,
+			wantCondition: adminConsoleApi.ReasonKeycloakNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ps := testHelper.NewFakePlatformService("https://admin-console.example.com")
+			ps.KeycloakClients[types.NamespacedName{Namespace: "edp", Name: "edp-admin-console"}] = keycloakV1Api.KeycloakClient{
+				ObjectMeta: metav1.ObjectMeta{Name: "edp-admin-console", Namespace: "edp", OwnerReferences: tt.clientOwners},
+			}
+			c := keycloakContributor{
+				platformService: ps,
+				client:          fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+			}
+			ac := adminConsoleApi.AdminConsole{
+				ObjectMeta: metav1.ObjectMeta{Name: "edp-admin-console", Namespace: "edp"},
+				Spec:       adminConsoleApi.AdminConsoleSpec{KeycloakSpec: adminConsoleApi.KeycloakSpec{Enabled: tt.enabled}},
+			}
+
+			contribution, err := c.Contribute(context.Background(), ac)
+			if tt.wantErr {
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(contribution.Env).Should(BeEmpty())
+				g.Expect(contribution.Conditions).Should(HaveLen(1))
+				g.Expect(contribution.Conditions[0].Reason).Should(Equal(tt.wantCondition))
+				return
+			}
+
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(contribution.Conditions).Should(BeEmpty())
+			for _, env := range tt.wantEnv {
+				g.Expect(contribution.Env).Should(ContainElement(env))
+			}
+			if !tt.enabled {
+				g.Expect(contribution.Env).Should(Equal(tt.wantEnv))
+			}
+		})
+	}
+}
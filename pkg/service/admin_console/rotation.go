@@ -0,0 +1,192 @@
+package admin_console
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dchest/uniuri"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/client/keycloak"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/client/postgres"
+	adminConsoleSpec "github.com/epam/edp-admin-console-operator/v2/pkg/service/admin_console/spec"
+)
+
+// RotateCredentials rotates the generated admin-console-reader and Keycloak client credentials when the
+// rotate-credentials annotation has changed or the rotation interval has elapsed, and restarts the admin
+// console so it picks them up. It returns the time left until the next scheduled rotation, zero if none.
+func (s AdminConsoleServiceImpl) RotateCredentials(ctx context.Context, instance adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, time.Duration, error) {
+	now := time.Now()
+	request := instance.GetAnnotations()[adminConsoleApi.RotateCredentialsAnnotation]
+
+	if !rotationRequested(instance, request) {
+		next := nextRotation(instance, now)
+		if next.IsZero() {
+			return &instance, 0, nil
+		}
+		if now.Before(next) {
+			return &instance, next.Sub(now), nil
+		}
+	}
+
+	var rotated []string
+
+	reader, err := s.rotateReaderPassword(ctx, instance)
+	if err != nil {
+		instance.SetConditionFalse(adminConsoleApi.ConditionCredentialsRotated, adminConsoleApi.ReasonCredentialsRotationFailed, err.Error())
+		return &instance, 0, err
+	}
+	if reader {
+		rotated = append(rotated, adminConsoleSpec.ReaderSecretName)
+	}
+
+	client, err := s.rotateKeycloakClientSecret(ctx, instance)
+	if err != nil {
+		instance.SetConditionFalse(adminConsoleApi.ConditionCredentialsRotated, adminConsoleApi.ReasonCredentialsRotationFailed, err.Error())
+		return &instance, 0, err
+	}
+	if client {
+		rotated = append(rotated, adminConsoleSpec.DefaultKeycloakSecretName)
+	}
+
+	if len(rotated) > 0 {
+		if err := s.platformService.RestartDeployment(ctx, instance); err != nil {
+			instance.SetConditionFalse(adminConsoleApi.ConditionCredentialsRotated, adminConsoleApi.ReasonCredentialsRotationFailed, err.Error())
+			return &instance, 0, errors.Wrap(err, "Failed to restart Admin Console after credentials rotation!")
+		}
+		instance.SetConditionTrue(adminConsoleApi.ConditionCredentialsRotated, adminConsoleApi.ReasonCredentialsRotated,
+			fmt.Sprintf("Rotated credentials in %s", strings.Join(rotated, ", ")))
+	} else {
+		instance.SetConditionTrue(adminConsoleApi.ConditionCredentialsRotated, adminConsoleApi.ReasonCredentialsRotated,
+			"No operator managed credentials to rotate")
+	}
+
+	rotationTime := metav1.NewTime(now)
+	instance.Status.LastRotationTime = &rotationTime
+	instance.Status.LastRotationRequest = request
+
+	next := nextRotation(instance, now)
+	if next.IsZero() {
+		return &instance, 0, nil
+	}
+	return &instance, next.Sub(now), nil
+}
+
+// rotationRequested reports whether the rotate-credentials annotation holds a value not handled yet.
+func rotationRequested(instance adminConsoleApi.AdminConsole, request string) bool {
+	return request != "" && request != instance.Status.LastRotationRequest
+}
+
+// nextRotation returns the time of the next scheduled rotation, counted from the last rotation or,
+// before the first one, from the creation of the admin console. It returns zero time if no interval is set.
+func nextRotation(instance adminConsoleApi.AdminConsole, now time.Time) time.Time {
+	if instance.Spec.Rotation == nil || instance.Spec.Rotation.Interval == nil || instance.Spec.Rotation.Interval.Duration <= 0 {
+		return time.Time{}
+	}
+
+	last := instance.CreationTimestamp.Time
+	if instance.Status.LastRotationTime != nil {
+		last = instance.Status.LastRotationTime.Time
+	}
+	if last.IsZero() {
+		last = now
+	}
+
+	return last.Add(instance.Spec.Rotation.Interval.Duration)
+}
+
+// rotateReaderPassword sets a new password for the read-only database role. The operator holds the
+// credentials to change the role only when it provisions the database, otherwise nothing is rotated,
+// as documented on spec.rotation.
+func (s AdminConsoleServiceImpl) rotateReaderPassword(ctx context.Context, instance adminConsoleApi.AdminConsole) (bool, error) {
+	db := instance.Spec.DbSpec
+	if !db.Enabled || db.Provisioning == nil {
+		return false, nil
+	}
+
+	admin, err := dbConnectionSettings(ctx, s.platformService, instance, db.Provisioning.AdminCredentialsSecretRef)
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to get database admin connection settings!")
+	}
+
+	reader, err := s.platformService.GetSecretData(ctx, instance.Namespace, adminConsoleSpec.ReaderSecretName)
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to get Admin Console read user credentials!")
+	}
+
+	// the secret is updated first, a failed attempt is retried with a new password and
+	// the provisioning aligns the role with the secret on the next reconciliation anyway
+	password := uniuri.New()
+	err = s.platformService.PatchSecretData(ctx, instance.Namespace, adminConsoleSpec.ReaderSecretName,
+		map[string][]byte{"password": []byte(password)})
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to update Admin Console read user password in secret")
+	}
+
+	if err := postgres.SetRolePassword(ctx, *admin, string(reader["username"]), password); err != nil {
+		return false, errors.Wrap(err, "Failed to change Admin Console read user password!")
+	}
+
+	return true, nil
+}
+
+// rotateKeycloakClientSecret sets a new secret for the confidential admin console client in Keycloak.
+func (s AdminConsoleServiceImpl) rotateKeycloakClientSecret(ctx context.Context, instance adminConsoleApi.AdminConsole) (bool, error) {
+	if !instance.Spec.KeycloakSpec.Enabled || instance.Spec.KeycloakSpec.Public {
+		return false, nil
+	}
+
+	keycloakClient, err := s.platformService.GetKeycloakClient(ctx, instance.Name, instance.Namespace)
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to get Keycloak client data!")
+	}
+
+	keycloakRealm, err := getOwnerKeycloakRealm(ctx, s.client, keycloakClient.ObjectMeta)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get keycloak realm cr")
+	}
+	if keycloakRealm == nil {
+		return false, errors.New("KeycloakRealm CR is not created yet!")
+	}
+
+	keycloakCr, err := getOwnerKeycloak(ctx, s.client, keycloakRealm.ObjectMeta)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to get owner for %s/%s", keycloakRealm.Namespace, keycloakRealm.Name)
+	}
+	if keycloakCr == nil {
+		return false, errors.New("Keycloak CR is not created yet!")
+	}
+
+	adminCredentials, err := s.platformService.GetSecretData(ctx, keycloakCr.Namespace, keycloakCr.Spec.Secret)
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to get Keycloak admin credentials!")
+	}
+
+	// Keycloak is changed first, so a failing Keycloak leaves both the client and the secret untouched. When the
+	// secret cannot be updated afterwards, the rotation is not recorded and is retried by the next reconciliation,
+	// which sets another password in Keycloak and then in the secret.
+	password := uniuri.New()
+	err = keycloak.SetClientSecret(ctx, keycloak.AdminCredentials{
+		Url:       keycloakCr.Spec.Url,
+		Username:  string(adminCredentials["username"]),
+		Password:  string(adminCredentials["password"]),
+		AdminType: keycloakCr.GetAdminType(),
+	}, keycloakRealm.Spec.RealmName, keycloakClient.Spec.ClientId, password)
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to change Keycloak client secret!")
+	}
+
+	err = s.platformService.PatchSecretData(ctx, instance.Namespace, adminConsoleSpec.DefaultKeycloakSecretName, map[string][]byte{
+		"password":     []byte(password),
+		"clientSecret": []byte(password),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to update Keycloak client secret in secret")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,132 @@
+package admin_console
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	testHelper "github.com/epam/edp-admin-console-operator/v2/test/helper"
+)
+
+func TestRotationRequested(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     string
+		lastRequest string
+		want        bool
+	}{
+		{name: "no annotation"},
+		{name: "new request", request: "2022-06-01", want: true},
+		{name: "request already handled", request: "2022-06-01", lastRequest: "2022-06-01"},
+		{name: "request changed", request: "2022-06-02", lastRequest: "2022-06-01", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ac := adminConsoleApi.AdminConsole{Status: adminConsoleApi.AdminConsoleStatus{LastRotationRequest: tt.lastRequest}}
+			g.Expect(rotationRequested(ac, tt.request)).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestNextRotation(t *testing.T) {
+	now := time.Date(2022, 6, 10, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-72 * time.Hour)
+	lastRotation := now.Add(-12 * time.Hour)
+
+	tests := []struct {
+		name         string
+		rotation     *adminConsoleApi.RotationPolicy
+		lastRotation *time.Time
+		want         time.Time
+	}{
+		{name: "no rotation configured"},
+		{name: "no interval", rotation: &adminConsoleApi.RotationPolicy{}},
+		{
+			name:     "before the first rotation",
+			rotation: &adminConsoleApi.RotationPolicy{Interval: &metav1.Duration{Duration: 24 * time.Hour}},
+			want:     created.Add(24 * time.Hour),
+		},
+		{
+			name:         "after a rotation",
+			rotation:     &adminConsoleApi.RotationPolicy{Interval: &metav1.Duration{Duration: 24 * time.Hour}},
+			lastRotation: &lastRotation,
+			want:         lastRotation.Add(24 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ac := adminConsoleApi.AdminConsole{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+				Spec:       adminConsoleApi.AdminConsoleSpec{Rotation: tt.rotation},
+			}
+			if tt.lastRotation != nil {
+				last := metav1.NewTime(*tt.lastRotation)
+				ac.Status.LastRotationTime = &last
+			}
+
+			g.Expect(nextRotation(ac, now)).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestRotateCredentials(t *testing.T) {
+	hourAgo := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name        string
+		interval    time.Duration
+		request     string
+		lastRequest string
+		wantRotated bool
+		wantRequeue bool
+	}{
+		{name: "no rotation configured"},
+		{name: "interval not elapsed", interval: 24 * time.Hour, wantRequeue: true},
+		{name: "interval elapsed", interval: 30 * time.Minute, wantRotated: true, wantRequeue: true},
+		{name: "annotation request", request: "1", wantRotated: true},
+		{name: "annotation request already handled", request: "1", lastRequest: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ac := adminConsoleApi.AdminConsole{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "edp-admin-console",
+					Namespace:   "edp",
+					Annotations: map[string]string{adminConsoleApi.RotateCredentialsAnnotation: tt.request},
+				},
+				Status: adminConsoleApi.AdminConsoleStatus{LastRotationTime: &hourAgo, LastRotationRequest: tt.lastRequest},
+			}
+			if tt.interval != 0 {
+				ac.Spec.Rotation = &adminConsoleApi.RotationPolicy{Interval: &metav1.Duration{Duration: tt.interval}}
+			}
+
+			s := AdminConsoleServiceImpl{platformService: testHelper.NewFakePlatformService("https://admin-console.example.com")}
+			result, requeue, err := s.RotateCredentials(context.Background(), ac)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(requeue > 0).Should(Equal(tt.wantRequeue))
+
+			if tt.wantRotated {
+				g.Expect(result.Status.LastRotationTime.After(hourAgo.Time)).Should(BeTrue())
+				g.Expect(result.Status.LastRotationRequest).Should(Equal(tt.request))
+				g.Expect(meta.FindStatusCondition(result.Status.Conditions, adminConsoleApi.ConditionCredentialsRotated)).ShouldNot(BeNil())
+			} else {
+				g.Expect(result.Status.LastRotationTime).Should(Equal(&hourAgo))
+				g.Expect(meta.FindStatusCondition(result.Status.Conditions, adminConsoleApi.ConditionCredentialsRotated)).Should(BeNil())
+			}
+		})
+	}
+}
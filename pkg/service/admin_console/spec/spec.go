@@ -2,6 +2,18 @@ package spec
 
 const (
 	DefaultKeycloakSecretName = "admin-console-client"
+	DefaultKeycloakClientId   = "admin-console-client"
+	DefaultKeycloakRealmRole  = "developer"
+	DefaultKeycloakScope      = "edp"
+	ReaderSecretName          = "admin-console-reader"
 	AdminConsolePort          = 8080
 	MemoryRequest             = "500Mi"
+	DefaultImage              = "epamedp/edp-admin-console"
+	DefaultVersion            = "latest"
+	DefaultReplicas           = 1
+	DefaultMinReplicas        = 1
+	DefaultTargetCPU          = 80
+	SecurityContextUser       = 1001
+	DbCaVolumeName            = "db-ca"
+	DbCaMountPath             = "/etc/admin-console/db-ca"
 )
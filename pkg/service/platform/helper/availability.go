@@ -0,0 +1,86 @@
+package helper
+
+import (
+	autoscalingV2beta2Api "k8s.io/api/autoscaling/v2beta2"
+	coreV1Api "k8s.io/api/core/v1"
+	policyV1beta1Api "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	adminConsoleSpec "github.com/epam/edp-admin-console-operator/v2/pkg/service/admin_console/spec"
+)
+
+// IsAutoscaled reports whether the admin console pods are scaled by a HorizontalPodAutoscaler.
+func IsAutoscaled(ac adminConsoleApi.AdminConsole) bool {
+	return ac.Spec.Availability != nil && ac.Spec.Availability.MaxReplicas > 0
+}
+
+// UseRollingUpdate reports whether the workload is rolled out pod by pod. A single replica is recreated, so two
+// admin console versions never run against the database at once; with more replicas or an autoscaler the pods
+// are replaced gradually, so the console stays available during the rollout.
+func UseRollingUpdate(ac adminConsoleApi.AdminConsole, replicas int32) bool {
+	return replicas > 1 || IsAutoscaled(ac)
+}
+
+// HasDisruptionBudget reports whether the admin console pods are protected by a PodDisruptionBudget.
+func HasDisruptionBudget(ac adminConsoleApi.AdminConsole) bool {
+	a := ac.Spec.Availability
+	return a != nil && (a.MinAvailable != nil || a.MaxUnavailable != nil)
+}
+
+// GetMinReplicas returns the lower autoscaling bound.
+func GetMinReplicas(ac adminConsoleApi.AdminConsole) int32 {
+	if ac.Spec.Availability == nil || ac.Spec.Availability.MinReplicas == nil {
+		return adminConsoleSpec.DefaultMinReplicas
+	}
+	return *ac.Spec.Availability.MinReplicas
+}
+
+// GetTargetCPUUtilization returns the average CPU utilization percentage the autoscaler aims at.
+func GetTargetCPUUtilization(ac adminConsoleApi.AdminConsole) int32 {
+	if ac.Spec.Availability == nil || ac.Spec.Availability.TargetCPUUtilizationPercentage == nil {
+		return adminConsoleSpec.DefaultTargetCPU
+	}
+	return *ac.Spec.Availability.TargetCPUUtilizationPercentage
+}
+
+// GenerateHPASpec returns the spec of the HorizontalPodAutoscaler scaling the workload between the autoscaling bounds.
+func GenerateHPASpec(ac adminConsoleApi.AdminConsole, target autoscalingV2beta2Api.CrossVersionObjectReference) autoscalingV2beta2Api.HorizontalPodAutoscalerSpec {
+	minReplicas := GetMinReplicas(ac)
+	utilization := GetTargetCPUUtilization(ac)
+
+	return autoscalingV2beta2Api.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: target,
+		MinReplicas:    &minReplicas,
+		MaxReplicas:    ac.Spec.Availability.MaxReplicas,
+		Metrics: []autoscalingV2beta2Api.MetricSpec{
+			{
+				Type: autoscalingV2beta2Api.ResourceMetricSourceType,
+				Resource: &autoscalingV2beta2Api.ResourceMetricSource{
+					Name: coreV1Api.ResourceCPU,
+					Target: autoscalingV2beta2Api.MetricTarget{
+						Type:               autoscalingV2beta2Api.UtilizationMetricType,
+						AverageUtilization: &utilization,
+					},
+				},
+			},
+		},
+	}
+}
+
+// GeneratePDBSpec returns the spec of the PodDisruptionBudget keeping the minimum number of admin console pods available,
+// or limiting the number of unavailable ones.
+func GeneratePDBSpec(ac adminConsoleApi.AdminConsole) policyV1beta1Api.PodDisruptionBudgetSpec {
+	spec := policyV1beta1Api.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{MatchLabels: GenerateLabels(ac.Name)},
+	}
+
+	if a := ac.Spec.Availability; a.MinAvailable != nil {
+		minAvailable := *a.MinAvailable
+		spec.MinAvailable = &minAvailable
+	} else {
+		maxUnavailable := *a.MaxUnavailable
+		spec.MaxUnavailable = &maxUnavailable
+	}
+	return spec
+}
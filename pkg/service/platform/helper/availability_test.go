@@ -0,0 +1,147 @@
+package helper
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	autoscalingV2beta2Api "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestGetReplicas(t *testing.T) {
+	tests := []struct {
+		name         string
+		replicas     *int32
+		availability *adminConsoleApi.AvailabilitySpec
+		current      *int32
+		want         int32
+	}{
+		{name: "default", want: 1},
+		{name: "spec replicas", replicas: int32Ptr(3), want: 3},
+		{
+			name:         "spec replicas without autoscaling",
+			replicas:     int32Ptr(3),
+			availability: &adminConsoleApi.AvailabilitySpec{MinAvailable: &intstr.IntOrString{IntVal: 1}},
+			current:      int32Ptr(5),
+			want:         3,
+		},
+		{
+			name:         "min replicas defaulted with autoscaling",
+			replicas:     int32Ptr(3),
+			availability: &adminConsoleApi.AvailabilitySpec{MaxReplicas: 4},
+			want:         1,
+		},
+		{
+			name:         "min replicas with autoscaling",
+			availability: &adminConsoleApi.AvailabilitySpec{MinReplicas: int32Ptr(2), MaxReplicas: 4},
+			want:         2,
+		},
+		{
+			name:         "current replicas kept with autoscaling",
+			availability: &adminConsoleApi.AvailabilitySpec{MinReplicas: int32Ptr(2), MaxReplicas: 4},
+			current:      int32Ptr(3),
+			want:         3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ac := testAdminConsole(adminConsoleApi.AdminConsoleSpec{Replicas: tt.replicas, Availability: tt.availability})
+			g.Expect(GetWorkloadReplicas(ac, tt.current)).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestIsAutoscaled(t *testing.T) {
+	tests := []struct {
+		name         string
+		availability *adminConsoleApi.AvailabilitySpec
+		want         bool
+	}{
+		{name: "no availability"},
+		{name: "maxReplicas unset", availability: &adminConsoleApi.AvailabilitySpec{MinReplicas: int32Ptr(2)}},
+		{name: "maxReplicas set", availability: &adminConsoleApi.AvailabilitySpec{MaxReplicas: 3}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(IsAutoscaled(testAdminConsole(adminConsoleApi.AdminConsoleSpec{Availability: tt.availability}))).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestGenerateHPASpec(t *testing.T) {
+	g := NewWithT(t)
+
+	target := autoscalingV2beta2Api.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "edp-admin-console"}
+
+	spec := GenerateHPASpec(testAdminConsole(adminConsoleApi.AdminConsoleSpec{
+		Availability: &adminConsoleApi.AvailabilitySpec{MaxReplicas: 3},
+	}), target)
+	g.Expect(spec.ScaleTargetRef).Should(Equal(target))
+	g.Expect(*spec.MinReplicas).Should(Equal(int32(1)))
+	g.Expect(spec.MaxReplicas).Should(Equal(int32(3)))
+	g.Expect(spec.Metrics).Should(HaveLen(1))
+	g.Expect(*spec.Metrics[0].Resource.Target.AverageUtilization).Should(Equal(int32(80)))
+
+	spec = GenerateHPASpec(testAdminConsole(adminConsoleApi.AdminConsoleSpec{
+		Availability: &adminConsoleApi.AvailabilitySpec{MinReplicas: int32Ptr(2), MaxReplicas: 5, TargetCPUUtilizationPercentage: int32Ptr(60)},
+	}), target)
+	g.Expect(*spec.MinReplicas).Should(Equal(int32(2)))
+	g.Expect(*spec.Metrics[0].Resource.Target.AverageUtilization).Should(Equal(int32(60)))
+}
+
+func TestGeneratePDBSpec(t *testing.T) {
+	percentage := intstr.FromString("50%")
+	one := intstr.FromInt(1)
+
+	tests := []struct {
+		name               string
+		availability       *adminConsoleApi.AvailabilitySpec
+		wantBudget         bool
+		wantMinAvailable   *intstr.IntOrString
+		wantMaxUnavailable *intstr.IntOrString
+	}{
+		{name: "no availability"},
+		{name: "autoscaling only", availability: &adminConsoleApi.AvailabilitySpec{MaxReplicas: 3}},
+		{
+			name:             "minAvailable",
+			availability:     &adminConsoleApi.AvailabilitySpec{MinAvailable: &percentage},
+			wantBudget:       true,
+			wantMinAvailable: &percentage,
+		},
+		{
+			name:               "maxUnavailable",
+			availability:       &adminConsoleApi.AvailabilitySpec{MaxUnavailable: &one},
+			wantBudget:         true,
+			wantMaxUnavailable: &one,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ac := testAdminConsole(adminConsoleApi.AdminConsoleSpec{Availability: tt.availability})
+			g.Expect(HasDisruptionBudget(ac)).Should(Equal(tt.wantBudget))
+			if !tt.wantBudget {
+				return
+			}
+
+			spec := GeneratePDBSpec(ac)
+			g.Expect(spec.MinAvailable).Should(Equal(tt.wantMinAvailable))
+			g.Expect(spec.MaxUnavailable).Should(Equal(tt.wantMaxUnavailable))
+			g.Expect(spec.Selector.MatchLabels).Should(Equal(GenerateLabels(ac.Name)))
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package helper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	coreV1Api "k8s.io/api/core/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+// ConfigChecksumAnnotation is the pod template annotation holding the checksum of the admin console ConfigMap,
+// so the pods are rolled out when the content of the ConfigMap changes and only then.
+const ConfigChecksumAnnotation = "v2.edp.epam.com/config-checksum"
+
+// WorkloadConfig is the configuration applied to the admin console container during integration.
+type WorkloadConfig struct {
+	// Env is set on the container.
+	Env []coreV1Api.EnvVar
+	// ConfigMapName is the ConfigMap the container reads the other settings from with envFrom, none when empty.
+	ConfigMapName string
+	// Checksum of the ConfigMap data, set on the pod template with ConfigChecksumAnnotation.
+	Checksum string
+}
+
+// UseConfigMap reports whether the generated settings are rendered into the admin console ConfigMap.
+func UseConfigMap(ac adminConsoleApi.AdminConsole) bool {
+	return ac.Spec.ConfigSource == adminConsoleApi.ConfigSourceConfigMap
+}
+
+// GetConfigMapName returns the name of the ConfigMap the generated settings are rendered into.
+func GetConfigMapName(ac adminConsoleApi.AdminConsole) string {
+	return ac.Name + "-config"
+}
+
+// GenerateWorkloadConfig returns the configuration of the admin console container for the generated env.
+// With the configMap source the plain values are moved to the returned ConfigMap data, the entries read
+// from secrets or fields are kept as env, as a ConfigMap must not hold credentials.
+func GenerateWorkloadConfig(ac adminConsoleApi.AdminConsole, env []coreV1Api.EnvVar) (WorkloadConfig, map[string]string) {
+	if !UseConfigMap(ac) {
+		return WorkloadConfig{Env: env}, nil
+	}
+
+	config := WorkloadConfig{ConfigMapName: GetConfigMapName(ac)}
+	data := map[string]string{}
+	for _, e := range env {
+		if e.ValueFrom != nil {
+			config.Env = append(config.Env, e)
+			continue
+		}
+		data[e.Name] = e.Value
+	}
+	config.Checksum = ConfigChecksum(data)
+
+	return config, data
+}
+
+// ConfigChecksum returns the SHA-256 of the ConfigMap data, which does not depend on the order of the keys.
+func ConfigChecksum(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(data[k]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,66 @@
+package helper
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	coreV1Api "k8s.io/api/core/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+func TestConfigChecksum(t *testing.T) {
+	data := map[string]string{"EDP_NAME": "edp", "PG_HOST": "edp-db"}
+
+	tests := []struct {
+		name     string
+		data     map[string]string
+		wantSame bool
+	}{
+		{name: "same settings", data: map[string]string{"PG_HOST": "edp-db", "EDP_NAME": "edp"}, wantSame: true},
+		{name: "changed value", data: map[string]string{"EDP_NAME": "edp", "PG_HOST": "edp-db-2"}},
+		{name: "added setting", data: map[string]string{"EDP_NAME": "edp", "PG_HOST": "edp-db", "PG_PORT": "5432"}},
+		{name: "removed setting", data: map[string]string{"EDP_NAME": "edp"}},
+		{name: "value moved to another key", data: map[string]string{"EDP_NAME": "", "PG_HOST": "edpedp-db"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			if tt.wantSame {
+				g.Expect(ConfigChecksum(tt.data)).Should(Equal(ConfigChecksum(data)))
+			} else {
+				g.Expect(ConfigChecksum(tt.data)).ShouldNot(Equal(ConfigChecksum(data)))
+			}
+		})
+	}
+}
+
+func TestGenerateWorkloadConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	ac := testAdminConsole(adminConsoleApi.AdminConsoleSpec{ConfigSource: adminConsoleApi.ConfigSourceConfigMap})
+	secretEnv := coreV1Api.EnvVar{Name: "PG_PASSWORD", ValueFrom: &coreV1Api.EnvVarSource{
+		SecretKeyRef: &coreV1Api.SecretKeySelector{
+			LocalObjectReference: coreV1Api.LocalObjectReference{Name: "admin-console-db"},
+			Key:                  "password",
+		},
+	}}
+	env := []coreV1Api.EnvVar{{Name: "PG_HOST", Value: "edp-db"}, secretEnv, {Name: "EDP_NAME", Value: "edp"}}
+
+	config, data := GenerateWorkloadConfig(ac, env)
+	g.Expect(config.Env).Should(Equal([]coreV1Api.EnvVar{secretEnv}))
+	g.Expect(config.ConfigMapName).Should(Equal(GetConfigMapName(ac)))
+	g.Expect(data).Should(Equal(map[string]string{"PG_HOST": "edp-db", "EDP_NAME": "edp"}))
+
+	reordered, _ := GenerateWorkloadConfig(ac, []coreV1Api.EnvVar{env[2], env[1], env[0]})
+	g.Expect(reordered.Checksum).Should(Equal(config.Checksum))
+
+	changed, _ := GenerateWorkloadConfig(ac, []coreV1Api.EnvVar{{Name: "PG_HOST", Value: "edp-db-2"}, secretEnv, env[2]})
+	g.Expect(changed.Checksum).ShouldNot(Equal(config.Checksum))
+
+	plain, data := GenerateWorkloadConfig(testAdminConsole(adminConsoleApi.AdminConsoleSpec{}), env)
+	g.Expect(plain).Should(Equal(WorkloadConfig{Env: env}))
+	g.Expect(data).Should(BeNil())
+}
@@ -0,0 +1,148 @@
+package helper
+
+import (
+	"path"
+	"strconv"
+
+	"github.com/pkg/errors"
+	coreV1Api "k8s.io/api/core/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	adminConsoleSpec "github.com/epam/edp-admin-console-operator/v2/pkg/service/admin_console/spec"
+)
+
+// GetDbCredentialsKeys returns the keys of the database user name and password in the referenced secret.
+func GetDbCredentialsKeys(ref *adminConsoleApi.DbCredentialsSecretRef) (string, string) {
+	usernameKey, passwordKey := adminConsoleApi.DefaultDbUsernameKey, adminConsoleApi.DefaultDbPasswordKey
+	if ref == nil {
+		return usernameKey, passwordKey
+	}
+	if ref.UsernameKey != "" {
+		usernameKey = ref.UsernameKey
+	}
+	if ref.PasswordKey != "" {
+		passwordKey = ref.PasswordKey
+	}
+	return usernameKey, passwordKey
+}
+
+// GetDbCaKey returns the key of the CA certificate in the CA secret.
+func GetDbCaKey(ac adminConsoleApi.AdminConsole) string {
+	if ref := ac.Spec.DbSpec.CaSecretRef; ref != nil && ref.Key != "" {
+		return ref.Key
+	}
+	return adminConsoleApi.DefaultDbCaKey
+}
+
+// GetDbCaPath returns the path of the database CA certificate inside the admin console container.
+func GetDbCaPath(ac adminConsoleApi.AdminConsole) string {
+	return path.Join(adminConsoleSpec.DbCaMountPath, GetDbCaKey(ac))
+}
+
+// GenerateDbEnv returns the environment variables the admin console connects to its database with. Empty connection
+// settings of an enabled database are a permanent error.
+func GenerateDbEnv(ac adminConsoleApi.AdminConsole) ([]coreV1Api.EnvVar, error) {
+	if !ac.Spec.DbSpec.Enabled {
+		return []coreV1Api.EnvVar{
+			{
+				Name:  "DB_ENABLED",
+				Value: "false",
+			},
+		}, nil
+	}
+
+	if ContainsEmptyString(ac.Spec.DbSpec.Name, ac.Spec.DbSpec.Hostname, ac.Spec.DbSpec.Port) {
+		return nil, NewPermanentError(errors.New("One or many DB settings field are empty!"))
+	}
+
+	env := []coreV1Api.EnvVar{
+		{
+			Name:  "PG_HOST",
+			Value: ac.Spec.DbSpec.Hostname,
+		},
+		{
+			Name:  "PG_PORT",
+			Value: ac.Spec.DbSpec.Port,
+		},
+		{
+			Name:  "PG_DATABASE",
+			Value: ac.Spec.DbSpec.Name,
+		},
+		{
+			Name:  "DB_ENABLED",
+			Value: strconv.FormatBool(ac.Spec.DbSpec.Enabled),
+		},
+	}
+
+	return append(env, GenerateDbConnectionEnv(ac)...), nil
+}
+
+// GenerateDbConnectionEnv returns the database credentials and TLS environment variables.
+// The credentials are referenced from the secret, so they never appear in the pod spec.
+func GenerateDbConnectionEnv(ac adminConsoleApi.AdminConsole) []coreV1Api.EnvVar {
+	var env []coreV1Api.EnvVar
+
+	if ref := ac.Spec.DbSpec.CredentialsSecretRef; ref != nil {
+		usernameKey, passwordKey := GetDbCredentialsKeys(ref)
+		env = append(env,
+			coreV1Api.EnvVar{
+				Name: "PG_USER",
+				ValueFrom: &coreV1Api.EnvVarSource{
+					SecretKeyRef: &coreV1Api.SecretKeySelector{
+						LocalObjectReference: coreV1Api.LocalObjectReference{Name: ref.Name},
+						Key:                  usernameKey,
+					},
+				},
+			},
+			coreV1Api.EnvVar{
+				Name: "PG_PASSWORD",
+				ValueFrom: &coreV1Api.EnvVarSource{
+					SecretKeyRef: &coreV1Api.SecretKeySelector{
+						LocalObjectReference: coreV1Api.LocalObjectReference{Name: ref.Name},
+						Key:                  passwordKey,
+					},
+				},
+			},
+		)
+	}
+
+	if ac.Spec.DbSpec.SslMode != "" {
+		env = append(env, coreV1Api.EnvVar{Name: "PG_SSLMODE", Value: ac.Spec.DbSpec.SslMode})
+	}
+
+	if ac.Spec.DbSpec.CaSecretRef != nil {
+		env = append(env, coreV1Api.EnvVar{Name: "PG_SSLROOTCERT", Value: GetDbCaPath(ac)})
+	}
+
+	return env
+}
+
+// dbCaVolume returns the volume and the mount with the database CA certificate, if one is referenced.
+func dbCaVolume(ac adminConsoleApi.AdminConsole) ([]coreV1Api.Volume, []coreV1Api.VolumeMount) {
+	ref := ac.Spec.DbSpec.CaSecretRef
+	if !ac.Spec.DbSpec.Enabled || ref == nil {
+		return nil, nil
+	}
+
+	volumes := []coreV1Api.Volume{
+		{
+			Name: adminConsoleSpec.DbCaVolumeName,
+			VolumeSource: coreV1Api.VolumeSource{
+				Secret: &coreV1Api.SecretVolumeSource{
+					SecretName: ref.Name,
+					Items: []coreV1Api.KeyToPath{
+						{Key: GetDbCaKey(ac), Path: GetDbCaKey(ac)},
+					},
+				},
+			},
+		},
+	}
+	mounts := []coreV1Api.VolumeMount{
+		{
+			Name:      adminConsoleSpec.DbCaVolumeName,
+			MountPath: adminConsoleSpec.DbCaMountPath,
+			ReadOnly:  true,
+		},
+	}
+	return volumes, mounts
+}
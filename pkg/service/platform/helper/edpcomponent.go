@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"sort"
+	"strings"
+
+	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
+	coreV1Api "k8s.io/api/core/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+// EDPComponentEnvPrefix prefixes the environment variables with the URLs of the EDPComponents, so they do not
+// clash with the settings of the other integrations, e.g. KEYCLOAK_URL. Nothing in this repository reads them:
+// the admin console image has to pick them up, e.g. in place of the links maintained in the chart values.
+const EDPComponentEnvPrefix = "EDP_COMPONENT_"
+
+// GenerateEDPComponentEnv returns an environment variable with the URL of every EDPComponent other than the one
+// published for the admin console itself, e.g. EDP_COMPONENT_SONAR_URL for the sonar EDPComponent. Names mapping
+// to the same variable, e.g. gerrit-ui and gerrit_ui, are resolved in favor of the first one in alphabetical order,
+// the env must not hold the same name twice.
+func GenerateEDPComponentEnv(ac adminConsoleApi.AdminConsole, components []edpCompApi.EDPComponent) []coreV1Api.EnvVar {
+	sorted := append([]edpCompApi.EDPComponent{}, components...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var env []coreV1Api.EnvVar
+	seen := map[string]bool{}
+	for _, c := range sorted {
+		if c.Name == ac.Name || c.Spec.Url == "" {
+			continue
+		}
+
+		name := EDPComponentEnvPrefix + envNameOf(c.Name) + "_URL"
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		env = append(env, coreV1Api.EnvVar{Name: name, Value: c.Spec.Url})
+	}
+
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+	return env
+}
+
+// envNameOf turns an object name into a part of an environment variable name, e.g. gerrit-ui into GERRIT_UI.
+func envNameOf(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
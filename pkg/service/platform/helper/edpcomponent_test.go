@@ -0,0 +1,44 @@
+package helper
+
+import (
+	"testing"
+
+	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
+	. "github.com/onsi/gomega"
+	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+func TestGenerateEDPComponentEnv(t *testing.T) {
+	g := NewWithT(t)
+
+	component := func(name, url string) edpCompApi.EDPComponent {
+		return edpCompApi.EDPComponent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "edp"},
+			Spec:       edpCompApi.EDPComponentSpec{Url: url},
+		}
+	}
+
+	ac := testAdminConsole(adminConsoleApi.AdminConsoleSpec{})
+	components := []edpCompApi.EDPComponent{
+		component("gerrit_ui", "https://gerrit-2.example.com"),
+		component("sonar", "https://sonar.example.com"),
+		component(ac.Name, "https://edp-admin-console.example.com"),
+		component("gerrit-ui", "https://gerrit.example.com"),
+		component("nexus", ""),
+	}
+
+	want := []coreV1Api.EnvVar{
+		{Name: "EDP_COMPONENT_GERRIT_UI_URL", Value: "https://gerrit.example.com"},
+		{Name: "EDP_COMPONENT_SONAR_URL", Value: "https://sonar.example.com"},
+	}
+	g.Expect(GenerateEDPComponentEnv(ac, components)).Should(Equal(want))
+
+	reversed := make([]edpCompApi.EDPComponent, 0, len(components))
+	for i := len(components) - 1; i >= 0; i-- {
+		reversed = append(reversed, components[i])
+	}
+	g.Expect(GenerateEDPComponentEnv(ac, reversed)).Should(Equal(want))
+}
@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// PermanentError is returned when the admin console cannot be reconciled as specified, e.g. a required field
+// of the spec is empty or the workload has no admin console container. Retrying does not help until the spec changes.
+type PermanentError struct {
+	err error
+}
+
+// NewPermanentError marks the error as permanent.
+func NewPermanentError(err error) error {
+	return &PermanentError{err: err}
+}
+
+func (e *PermanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.err
+}
+
+// IsPermanent reports whether the error, or any error it wraps, is permanent: a PermanentError or an object
+// rejected by the API server as invalid. Any other error, e.g. a conflict, a timeout or a dependency which
+// does not exist yet, is transient.
+func IsPermanent(err error) bool {
+	var target *PermanentError
+	return errors.As(err, &target) || k8sErrors.IsInvalid(err)
+}
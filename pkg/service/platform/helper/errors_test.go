@@ -0,0 +1,44 @@
+package helper
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	pkgErrors "github.com/pkg/errors"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestIsPermanent(t *testing.T) {
+	deployment := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	invalid := k8sErrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "edp-admin-console",
+		field.ErrorList{field.Required(field.NewPath("spec", "template"), "")})
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "permanent", err: NewPermanentError(fmt.Errorf("dnsWildcard is empty")), want: true},
+		{name: "wrapped permanent", err: pkgErrors.Wrap(NewPermanentError(fmt.Errorf("no container")), "unable to update deployment"), want: true},
+		{name: "permanent wrapped with %w", err: fmt.Errorf("reconcile failed: %w", NewPermanentError(fmt.Errorf("no container"))), want: true},
+		{name: "invalid", err: invalid, want: true},
+		{name: "wrapped invalid", err: pkgErrors.Wrap(invalid, "unable to create deployment"), want: true},
+		{name: "not found", err: k8sErrors.NewNotFound(deployment, "edp-admin-console")},
+		{name: "forbidden", err: k8sErrors.NewForbidden(deployment, "edp-admin-console", fmt.Errorf("no access"))},
+		{name: "conflict", err: k8sErrors.NewConflict(deployment, "edp-admin-console", fmt.Errorf("modified"))},
+		{name: "timeout", err: k8sErrors.NewTimeoutError("request timed out", 1)},
+		{name: "plain", err: fmt.Errorf("connection refused")},
+		{name: "nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(IsPermanent(tt.err)).Should(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package helper
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+const (
+	gatewayGroup        = "gateway.networking.k8s.io"
+	gatewayVersion      = "v1beta1"
+	pathMatchPathPrefix = "PathPrefix"
+	protocolHTTPS       = "HTTPS"
+)
+
+var (
+	// HTTPRouteGVK is the Gateway API HTTPRoute kind. Gateway API types are not vendored, HTTPRoutes
+	// and Gateways are handled as unstructured objects.
+	HTTPRouteGVK = schema.GroupVersionKind{Group: gatewayGroup, Version: gatewayVersion, Kind: "HTTPRoute"}
+	// GatewayGVK is the Gateway API Gateway kind.
+	GatewayGVK = schema.GroupVersionKind{Group: gatewayGroup, Version: gatewayVersion, Kind: "Gateway"}
+)
+
+// GetExposure returns how the admin console is exposed, the platform default when the spec does not set it.
+func GetExposure(ac adminConsoleApi.AdminConsole, platformType string) string {
+	if ac.Spec.Exposure != "" {
+		return ac.Spec.Exposure
+	}
+	if platformType == "openshift" {
+		return adminConsoleApi.ExposureRoute
+	}
+	return adminConsoleApi.ExposureIngress
+}
+
+// GetHTTPRouteName returns the name of the HTTPRoute the admin console URL is read from.
+func GetHTTPRouteName(ac adminConsoleApi.AdminConsole) string {
+	if ac.Spec.Gateway != nil && ac.Spec.Gateway.RouteName != "" {
+		return ac.Spec.Gateway.RouteName
+	}
+	return ac.Name
+}
+
+// IsHTTPRouteManaged reports whether the operator creates the HTTPRoute or only reads an existing one.
+func IsHTTPRouteManaged(ac adminConsoleApi.AdminConsole) bool {
+	return ac.Spec.Gateway == nil || ac.Spec.Gateway.RouteName == ""
+}
+
+// NewUnstructured returns an empty unstructured object of the given kind.
+func NewUnstructured(gvk schema.GroupVersionKind, namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+// GenerateHTTPRouteSpec returns the spec of the HTTPRoute which sends the admin console host and path to its Service.
+// Fields defaulted by the Gateway API are set explicitly, so an unchanged route is not updated on every reconciliation.
+func GenerateHTTPRouteSpec(ac adminConsoleApi.AdminConsole) map[string]interface{} {
+	var parentRefs []interface{}
+	if ac.Spec.Gateway != nil {
+		for _, ref := range ac.Spec.Gateway.ParentRefs {
+			parentRef := map[string]interface{}{
+				"group": gatewayGroup,
+				"kind":  GatewayGVK.Kind,
+				"name":  ref.Name,
+			}
+			if ref.Namespace != "" {
+				parentRef["namespace"] = ref.Namespace
+			}
+			if ref.SectionName != "" {
+				parentRef["sectionName"] = ref.SectionName
+			}
+			parentRefs = append(parentRefs, parentRef)
+		}
+	}
+
+	return map[string]interface{}{
+		"parentRefs": parentRefs,
+		"hostnames":  []interface{}{GetHost(ac)},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  pathMatchPathPrefix,
+							"value": GetPath(ac),
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"group":  "",
+						"kind":   "Service",
+						"name":   ac.Name,
+						"port":   int64(GetPort(ac)),
+						"weight": int64(1),
+					},
+				},
+			},
+		},
+	}
+}
+
+// HTTPRouteParent is the Gateway listener an HTTPRoute is attached to.
+type HTTPRouteParent struct {
+	Namespace   string
+	Name        string
+	SectionName string
+}
+
+// GetHTTPRouteParent returns the first Gateway the route is attached to.
+func GetHTTPRouteParent(route *unstructured.Unstructured) (*HTTPRouteParent, error) {
+	refs, _, err := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid parentRefs in HTTPRoute %s", route.GetName())
+	}
+
+	for _, r := range refs {
+		ref, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if kind, ok := ref["kind"].(string); ok && kind != GatewayGVK.Kind {
+			continue
+		}
+
+		parent := &HTTPRouteParent{Namespace: route.GetNamespace()}
+		parent.Name, _ = ref["name"].(string)
+		if ns, ok := ref["namespace"].(string); ok && ns != "" {
+			parent.Namespace = ns
+		}
+		parent.SectionName, _ = ref["sectionName"].(string)
+		return parent, nil
+	}
+
+	return nil, errors.Errorf("HTTPRoute %s is not attached to a Gateway", route.GetName())
+}
+
+// GetHTTPRouteHost returns the first hostname of the route, or the hostname of the listener
+// the route is attached to when the route has none.
+func GetHTTPRouteHost(route, gateway *unstructured.Unstructured, sectionName string) (string, error) {
+	hostnames, _, err := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid hostnames in HTTPRoute %s", route.GetName())
+	}
+	if len(hostnames) > 0 {
+		return hostnames[0], nil
+	}
+
+	listener := selectListener(gateway, sectionName, "")
+	if listener != nil {
+		if host, ok := listener["hostname"].(string); ok && host != "" && !strings.HasPrefix(host, "*") {
+			return host, nil
+		}
+	}
+
+	return "", &NoHostError{Kind: HTTPRouteGVK.Kind, Namespace: route.GetNamespace(), Name: route.GetName()}
+}
+
+// GetHTTPRouteScheme returns https when the Gateway listener serving the host terminates TLS, http otherwise.
+func GetHTTPRouteScheme(gateway *unstructured.Unstructured, sectionName, host string) string {
+	listener := selectListener(gateway, sectionName, host)
+	if listener == nil {
+		return "http"
+	}
+	if protocol, _ := listener["protocol"].(string); protocol == protocolHTTPS {
+		return "https"
+	}
+	return "http"
+}
+
+// GetHTTPRoutePath returns the first path the route matches.
+func GetHTTPRoutePath(route *unstructured.Unstructured) string {
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	for _, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		matches, _, _ := unstructured.NestedSlice(rule, "matches")
+		for _, m := range matches {
+			match, ok := m.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			value, found, _ := unstructured.NestedString(match, "path", "value")
+			if !found {
+				continue
+			}
+			return value
+		}
+	}
+	return ""
+}
+
+// GenerateHTTPRouteUrl returns the external URL of the admin console exposed by the route attached to the gateway.
+func GenerateHTTPRouteUrl(route, gateway *unstructured.Unstructured, sectionName string) (string, error) {
+	host, err := GetHTTPRouteHost(route, gateway, sectionName)
+	if err != nil {
+		return "", err
+	}
+
+	return GenerateUrl(GetHTTPRouteScheme(gateway, sectionName, host), host, GetHTTPRoutePath(route)), nil
+}
+
+// selectListener returns the listener with the given name, or else the listener whose hostname matches the host,
+// preferring HTTPS listeners. An empty host matches any listener.
+func selectListener(gateway *unstructured.Unstructured, sectionName, host string) map[string]interface{} {
+	listeners, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+
+	var match map[string]interface{}
+	for _, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if sectionName != "" {
+			if name, _ := listener["name"].(string); name == sectionName {
+				return listener
+			}
+			continue
+		}
+
+		hostname, _ := listener["hostname"].(string)
+		if !hostMatches(hostname, host) {
+			continue
+		}
+		if protocol, _ := listener["protocol"].(string); protocol == protocolHTTPS {
+			return listener
+		}
+		if match == nil {
+			match = listener
+		}
+	}
+
+	return match
+}
+
+// hostMatches reports whether the host is served by a listener with the given hostname, which may be a wildcard.
+func hostMatches(listenerHost, host string) bool {
+	if listenerHost == "" || host == "" {
+		return true
+	}
+	if strings.HasPrefix(listenerHost, "*.") {
+		return strings.HasSuffix(host, listenerHost[1:])
+	}
+	return listenerHost == host
+}
@@ -0,0 +1,220 @@
+package helper
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func testHTTPRoute(spec map[string]interface{}) *unstructured.Unstructured {
+	route := NewUnstructured(HTTPRouteGVK, "edp", "edp-admin-console")
+	route.Object["spec"] = spec
+	return route
+}
+
+func testGateway(listeners ...map[string]interface{}) *unstructured.Unstructured {
+	var items []interface{}
+	for _, l := range listeners {
+		items = append(items, l)
+	}
+	gateway := NewUnstructured(GatewayGVK, "gateways", "main")
+	gateway.Object["spec"] = map[string]interface{}{"listeners": items}
+	return gateway
+}
+
+func listener(name, hostname, protocol string) map[string]interface{} {
+	l := map[string]interface{}{"name": name, "protocol": protocol}
+	if hostname != "" {
+		l["hostname"] = hostname
+	}
+	return l
+}
+
+func TestGetHTTPRouteParent(t *testing.T) {
+	tests := []struct {
+		name       string
+		parentRefs []interface{}
+		want       *HTTPRouteParent
+	}{
+		{
+			name:       "gateway in the route namespace",
+			parentRefs: []interface{}{map[string]interface{}{"name": "main"}},
+			want:       &HTTPRouteParent{Namespace: "edp", Name: "main"},
+		},
+		{
+			name: "gateway in another namespace with a section name",
+			parentRefs: []interface{}{map[string]interface{}{
+				"kind": "Gateway", "name": "main", "namespace": "gateways", "sectionName": "https",
+			}},
+			want: &HTTPRouteParent{Namespace: "gateways", Name: "main", SectionName: "https"},
+		},
+		{
+			name: "other parents skipped",
+			parentRefs: []interface{}{
+				map[string]interface{}{"kind": "Service", "name": "mesh"},
+				map[string]interface{}{"kind": "Gateway", "name": "main"},
+			},
+			want: &HTTPRouteParent{Namespace: "edp", Name: "main"},
+		},
+		{name: "not attached"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			parent, err := GetHTTPRouteParent(testHTTPRoute(map[string]interface{}{"parentRefs": tt.parentRefs}))
+			if tt.want == nil {
+				g.Expect(err).Should(HaveOccurred())
+				return
+			}
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(parent).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetHTTPRouteHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		hostnames   []interface{}
+		gateway     *unstructured.Unstructured
+		sectionName string
+		want        string
+	}{
+		{
+			name:      "route hostname",
+			hostnames: []interface{}{"console.example.com", "other.example.com"},
+			gateway:   testGateway(listener("http", "gateway.example.com", "HTTP")),
+			want:      "console.example.com",
+		},
+		{
+			name:    "listener hostname",
+			gateway: testGateway(listener("http", "gateway.example.com", "HTTP")),
+			want:    "gateway.example.com",
+		},
+		{
+			name:        "listener selected by section name",
+			gateway:     testGateway(listener("http", "http.example.com", "HTTP"), listener("https", "https.example.com", "HTTPS")),
+			sectionName: "http",
+			want:        "http.example.com",
+		},
+		{
+			name:    "https listener preferred",
+			gateway: testGateway(listener("http", "http.example.com", "HTTP"), listener("https", "https.example.com", "HTTPS")),
+			want:    "https.example.com",
+		},
+		{
+			name:    "wildcard listener hostname",
+			gateway: testGateway(listener("https", "*.example.com", "HTTPS")),
+		},
+		{
+			name:    "listener without hostname",
+			gateway: testGateway(listener("http", "", "HTTP")),
+		},
+		{
+			name:        "unknown section name",
+			gateway:     testGateway(listener("http", "http.example.com", "HTTP")),
+			sectionName: "https",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			route := testHTTPRoute(map[string]interface{}{"hostnames": tt.hostnames})
+			host, err := GetHTTPRouteHost(route, tt.gateway, tt.sectionName)
+			if tt.want == "" {
+				g.Expect(IsNoHost(err)).Should(BeTrue())
+				return
+			}
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(host).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestGenerateHTTPRouteUrl(t *testing.T) {
+	g := NewWithT(t)
+
+	route := testHTTPRoute(map[string]interface{}{
+		"hostnames": []interface{}{"console.example.com"},
+		"rules": []interface{}{map[string]interface{}{
+			"matches": []interface{}{map[string]interface{}{"path": map[string]interface{}{"type": "PathPrefix", "value": "/console/"}}},
+		}},
+	})
+
+	url, err := GenerateHTTPRouteUrl(route, testGateway(
+		listener("http", "", "HTTP"),
+		listener("https", "*.example.com", "HTTPS"),
+	), "")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(url).Should(Equal("https://console.example.com/console"))
+
+	url, err = GenerateHTTPRouteUrl(route, testGateway(
+		listener("http", "", "HTTP"),
+		listener("https", "*.other.com", "HTTPS"),
+	), "")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(url).Should(Equal("http://console.example.com/console"))
+}
+
+func TestSelectListener(t *testing.T) {
+	http := listener("http", "", "HTTP")
+	httpsWildcard := listener("https", "*.example.com", "HTTPS")
+	httpsOther := listener("other", "other.com", "HTTPS")
+
+	tests := []struct {
+		name        string
+		gateway     *unstructured.Unstructured
+		sectionName string
+		host        string
+		want        map[string]interface{}
+	}{
+		{name: "section name", gateway: testGateway(http, httpsWildcard), sectionName: "http", host: "console.example.com", want: http},
+		{name: "unknown section name", gateway: testGateway(http, httpsWildcard), sectionName: "grpc"},
+		{name: "https preferred", gateway: testGateway(http, httpsWildcard), host: "console.example.com", want: httpsWildcard},
+		{name: "https listener for another host", gateway: testGateway(http, httpsOther), host: "console.example.com", want: http},
+		{name: "no matching listener", gateway: testGateway(httpsOther), host: "console.example.com"},
+		{name: "no listeners", gateway: testGateway()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := selectListener(tt.gateway, tt.sectionName, tt.host)
+			if tt.want == nil {
+				g.Expect(got).Should(BeNil())
+				return
+			}
+			g.Expect(got).Should(Equal(tt.want))
+		})
+	}
+}
+
+func TestHostMatches(t *testing.T) {
+	tests := []struct {
+		listenerHost string
+		host         string
+		want         bool
+	}{
+		{listenerHost: "", host: "console.example.com", want: true},
+		{listenerHost: "console.example.com", host: "", want: true},
+		{listenerHost: "console.example.com", host: "console.example.com", want: true},
+		{listenerHost: "console.example.com", host: "other.example.com"},
+		{listenerHost: "*.example.com", host: "console.example.com", want: true},
+		{listenerHost: "*.example.com", host: "example.com"},
+		{listenerHost: "*.example.com", host: "console.example.org"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.listenerHost+" "+tt.host, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(hostMatches(tt.listenerHost, tt.host)).Should(Equal(tt.want))
+		})
+	}
+}
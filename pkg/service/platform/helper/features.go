@@ -0,0 +1,31 @@
+package helper
+
+import (
+	"strings"
+
+	coreV1Api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+// GenerateFeatureEnv returns an environment variable with the comma-separated values of every non-empty feature
+// list of spec.features. Unknown values are a permanent error, they are rejected by the webhook when it is enabled.
+func GenerateFeatureEnv(ac adminConsoleApi.AdminConsole) ([]coreV1Api.EnvVar, error) {
+	if errs := adminConsoleApi.ValidateFeatures(ac.Spec.Features, field.NewPath("spec", "features")); len(errs) != 0 {
+		return nil, NewPermanentError(errs.ToAggregate())
+	}
+
+	var env []coreV1Api.EnvVar
+	for _, list := range ac.Spec.Features.FeatureLists() {
+		if len(list.Values) == 0 {
+			continue
+		}
+		env = append(env, coreV1Api.EnvVar{
+			Name:  list.EnvVar,
+			Value: strings.Join(list.Values, ","),
+		})
+	}
+
+	return env, nil
+}
@@ -1,13 +1,19 @@
 package helper
 
 import (
+	"encoding/json"
 	"fmt"
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
 	util "github.com/epam/edp-admin-console-operator/v2/pkg/controller/helper"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/helper"
 	"github.com/pkg/errors"
 	"github.com/totherme/unstructured"
 	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"net/url"
+	"time"
 )
 
 const (
@@ -78,7 +84,7 @@ func SelectContainer(containers []coreV1Api.Container, name string) (coreV1Api.C
 		}
 	}
 
-	return out, errors.New("No matching container in spec found!")
+	return out, NewPermanentError(errors.New("No matching container in spec found!"))
 }
 
 func UpdateEnv(existing []coreV1Api.EnvVar, env []coreV1Api.EnvVar) []coreV1Api.EnvVar {
@@ -113,6 +119,17 @@ func findEnv(env []coreV1Api.EnvVar, name string) (coreV1Api.EnvVar, bool) {
 	return coreV1Api.EnvVar{}, false
 }
 
+// RemoveOwnerReference returns owner references without the one pointing to the owner with the given UID.
+func RemoveOwnerReference(refs []metav1.OwnerReference, uid types.UID) []metav1.OwnerReference {
+	var out []metav1.OwnerReference
+	for _, r := range refs {
+		if r.UID != uid {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
 func ContainsEmptyString(ss ...string) bool {
 	for _, s := range ss {
 		if s == "" {
@@ -145,3 +162,65 @@ func createPath(directory string, localRun bool) (string, error) {
 	return templatePath, nil
 
 }
+
+// RestartedAtAnnotation is the pod template annotation kubectl rollout restart sets to roll the pods out.
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// EnvFieldManager is the field manager the configuration generated during integration is applied with. It owns only
+// the env entries, the envFrom and the checksum annotation it has applied, so what it no longer generates is removed
+// by the next apply.
+const EnvFieldManager = "admin-console-operator-env"
+
+// GenerateEnvApplyPatch returns the server-side apply configuration of the admin console workload of the given kind,
+// which holds nothing but the configuration of the admin console container.
+func GenerateEnvApplyPatch(gvk schema.GroupVersionKind, ac adminConsoleApi.AdminConsole, config WorkloadConfig) ([]byte, error) {
+	container := map[string]interface{}{"name": ac.Name}
+	if len(config.Env) != 0 {
+		container["env"] = config.Env
+	}
+	if config.ConfigMapName != "" {
+		container["envFrom"] = []coreV1Api.EnvFromSource{{
+			ConfigMapRef: &coreV1Api.ConfigMapEnvSource{
+				LocalObjectReference: coreV1Api.LocalObjectReference{Name: config.ConfigMapName},
+			},
+		}}
+	}
+
+	template := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{container},
+		},
+	}
+	if config.Checksum != "" {
+		template["metadata"] = map[string]interface{}{
+			"annotations": map[string]string{ConfigChecksumAnnotation: config.Checksum},
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind,
+		"metadata": map[string]interface{}{
+			"name":      ac.Name,
+			"namespace": ac.Namespace,
+		},
+		"spec": map[string]interface{}{
+			"template": template,
+		},
+	})
+}
+
+// GenerateRestartPatch returns a merge patch which rolls the pods of a workload out, like kubectl rollout restart.
+func GenerateRestartPatch(now time.Time) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						RestartedAtAnnotation: now.Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	})
+}
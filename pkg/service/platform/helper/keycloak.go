@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"strconv"
+
+	coreV1Api "k8s.io/api/core/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	adminConsoleSpec "github.com/epam/edp-admin-console-operator/v2/pkg/service/admin_console/spec"
+)
+
+// GetKeycloakClientId returns the Keycloak client ID of the admin console.
+func GetKeycloakClientId(ac adminConsoleApi.AdminConsole) string {
+	if ac.Spec.KeycloakSpec.ClientId == "" {
+		return adminConsoleSpec.DefaultKeycloakClientId
+	}
+	return ac.Spec.KeycloakSpec.ClientId
+}
+
+// GetKeycloakRealmRoles returns the realm roles of the Keycloak client service account.
+func GetKeycloakRealmRoles(ac adminConsoleApi.AdminConsole) []string {
+	if len(ac.Spec.KeycloakSpec.RealmRoles) == 0 {
+		return []string{adminConsoleSpec.DefaultKeycloakRealmRole}
+	}
+	return ac.Spec.KeycloakSpec.RealmRoles
+}
+
+// GetKeycloakDefaultClientScopes returns the default client scopes of the Keycloak client.
+func GetKeycloakDefaultClientScopes(ac adminConsoleApi.AdminConsole) []string {
+	if len(ac.Spec.KeycloakSpec.DefaultClientScopes) == 0 {
+		return []string{adminConsoleSpec.DefaultKeycloakScope}
+	}
+	return ac.Spec.KeycloakSpec.DefaultClientScopes
+}
+
+// GetKeycloakDirectAccess returns whether direct access grants are enabled for the Keycloak client.
+func GetKeycloakDirectAccess(ac adminConsoleApi.AdminConsole) bool {
+	if ac.Spec.KeycloakSpec.DirectAccess == nil {
+		return true
+	}
+	return *ac.Spec.KeycloakSpec.DirectAccess
+}
+
+// GenerateKeycloakEnv returns the environment variables the admin console authenticates with against the Keycloak
+// realm of the discovery URL. The client credentials are referenced from the Keycloak client secret. With Keycloak
+// disabled only AUTH_KEYCLOAK_ENABLED is returned, so an earlier enabled value does not stay in the container.
+func GenerateKeycloakEnv(ac adminConsoleApi.AdminConsole, keycloakUrl string) []coreV1Api.EnvVar {
+	if !ac.Spec.KeycloakSpec.Enabled {
+		return []coreV1Api.EnvVar{
+			{
+				Name:  "AUTH_KEYCLOAK_ENABLED",
+				Value: "false",
+			},
+		}
+	}
+
+	return []coreV1Api.EnvVar{
+		{
+			Name: "KEYCLOAK_CLIENT_ID",
+			ValueFrom: &coreV1Api.EnvVarSource{
+				SecretKeyRef: &coreV1Api.SecretKeySelector{
+					LocalObjectReference: coreV1Api.LocalObjectReference{
+						Name: adminConsoleSpec.DefaultKeycloakSecretName,
+					},
+					Key: "username",
+				},
+			},
+		},
+		{
+			Name: "KEYCLOAK_CLIENT_SECRET",
+			ValueFrom: &coreV1Api.EnvVarSource{
+				SecretKeyRef: &coreV1Api.SecretKeySelector{
+					LocalObjectReference: coreV1Api.LocalObjectReference{
+						Name: adminConsoleSpec.DefaultKeycloakSecretName,
+					},
+					Key: "password",
+				},
+			},
+		},
+		{
+			Name:  "KEYCLOAK_URL",
+			Value: keycloakUrl,
+		},
+		{
+			Name:  "AUTH_KEYCLOAK_ENABLED",
+			Value: strconv.FormatBool(ac.Spec.KeycloakSpec.Enabled),
+		},
+	}
+}
@@ -0,0 +1,197 @@
+package helper
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HasAppliedEnv reports whether the configuration has been applied to the workload with EnvFieldManager.
+func HasAppliedEnv(managedFields []metav1.ManagedFieldsEntry) bool {
+	for _, m := range managedFields {
+		if m.Manager == EnvFieldManager && m.Operation == metav1.ManagedFieldsOperationApply {
+			return true
+		}
+	}
+	return false
+}
+
+// GetKeptEnv returns the names of the env entries of the container MergePodTemplate keeps besides the generated ones,
+// which are the entries applied with EnvFieldManager. Until the configuration has been applied, e.g. on a workload
+// created by an earlier operator version, all entries are kept, so the env set during integration is not removed
+// before the first apply takes it over.
+func GetKeptEnv(managedFields []metav1.ManagedFieldsEntry, template coreV1Api.PodTemplateSpec, containerName string) []string {
+	if HasAppliedEnv(managedFields) {
+		return GetAppliedEnv(managedFields, containerName)
+	}
+
+	container, err := SelectContainer(template.Spec.Containers, containerName)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(container.Env))
+	for _, e := range container.Env {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+// GetAppliedEnv returns the names of the env entries of the container that were applied with EnvFieldManager,
+// as recorded in the managed fields of the workload.
+func GetAppliedEnv(managedFields []metav1.ManagedFieldsEntry, containerName string) []string {
+	var names []string
+	for _, m := range managedFields {
+		if m.Manager != EnvFieldManager || m.Operation != metav1.ManagedFieldsOperationApply {
+			continue
+		}
+
+		fields, err := decodeFields(m)
+		if err != nil {
+			continue
+		}
+
+		env, _ := lookupFields(fields, containerFieldPath(containerName, "f:env")...)
+		for k := range env {
+			if name, ok := keyName(k); ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// GenerateEnvMigrationPatch returns a JSON patch removing the fields the configuration is applied to from the other
+// field managers of the workload, e.g. the Update manager of an earlier operator version or helm. The first apply
+// with EnvFieldManager then owns these fields alone instead of failing with a conflict or sharing them, which would
+// keep the entries it no longer generates. The workload is migrated once: nil is returned when the configuration
+// has been applied before or no other manager owns these fields. The patch fails when the workload has changed since
+// it was read.
+func GenerateEnvMigrationPatch(meta metav1.ObjectMeta, containerName string, config WorkloadConfig) ([]byte, error) {
+	if HasAppliedEnv(meta.ManagedFields) {
+		return nil, nil
+	}
+
+	released := false
+	managedFields := make([]metav1.ManagedFieldsEntry, 0, len(meta.ManagedFields))
+	for _, m := range meta.ManagedFields {
+		fields, err := decodeFields(m)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode fields managed by %s", m.Manager)
+		}
+
+		if releaseConfigFields(fields, containerName, config) {
+			released = true
+			if len(fields) == 0 {
+				continue
+			}
+
+			raw, err := json.Marshal(fields)
+			if err != nil {
+				return nil, err
+			}
+			m.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+		}
+		managedFields = append(managedFields, m)
+	}
+
+	if !released {
+		return nil, nil
+	}
+
+	return json.Marshal([]map[string]interface{}{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": meta.ResourceVersion},
+		{"op": "replace", "path": "/metadata/managedFields", "value": managedFields},
+	})
+}
+
+// releaseConfigFields removes the env entries of the config, the envFrom and the checksum annotation from the field
+// set and reports whether any of them was there.
+func releaseConfigFields(fields map[string]interface{}, containerName string, config WorkloadConfig) bool {
+	released := false
+	for _, e := range config.Env {
+		released = removeField(fields, containerFieldPath(containerName, "f:env", nameKey(e.Name))...) || released
+	}
+	if config.ConfigMapName != "" {
+		released = removeField(fields, containerFieldPath(containerName, "f:envFrom")...) || released
+	}
+	if config.Checksum != "" {
+		released = removeField(fields, "f:spec", "f:template", "f:metadata", "f:annotations", "f:"+ConfigChecksumAnnotation) || released
+	}
+	return released
+}
+
+// removeField removes the field at the path and the parents it leaves empty.
+func removeField(fields map[string]interface{}, path ...string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	if len(path) == 1 {
+		if _, ok := fields[path[0]]; !ok {
+			return false
+		}
+		delete(fields, path[0])
+		return true
+	}
+
+	next, ok := fields[path[0]].(map[string]interface{})
+	if !ok || !removeField(next, path[1:]...) {
+		return false
+	}
+	if len(next) == 0 {
+		delete(fields, path[0])
+	}
+	return true
+}
+
+// containerFieldPath returns the path of a field of the named container of the pod template in a managed field set.
+func containerFieldPath(containerName string, field ...string) []string {
+	return append([]string{"f:spec", "f:template", "f:spec", "f:containers", nameKey(containerName)}, field...)
+}
+
+func decodeFields(m metav1.ManagedFieldsEntry) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if m.FieldsV1 == nil {
+		return fields, nil
+	}
+	err := json.Unmarshal(m.FieldsV1.Raw, &fields)
+	return fields, err
+}
+
+func lookupFields(fields map[string]interface{}, path ...string) (map[string]interface{}, bool) {
+	for _, p := range path {
+		next, ok := fields[p].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		fields = next
+	}
+	return fields, true
+}
+
+// nameKey returns the key of the list item with the given name in a managed field set.
+func nameKey(name string) string {
+	key, _ := json.Marshal(map[string]string{"name": name})
+	return "k:" + string(key)
+}
+
+func keyName(key string) (string, bool) {
+	if !strings.HasPrefix(key, "k:") {
+		return "", false
+	}
+
+	item := struct {
+		Name string `json:"name"`
+	}{}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "k:")), &item); err != nil || item.Name == "" {
+		return "", false
+	}
+	return item.Name, true
+}
@@ -0,0 +1,157 @@
+package helper
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func containerFields(env ...string) string {
+	entries := ""
+	for i, name := range env {
+		if i > 0 {
+			entries += ","
+		}
+		entries += `"k:{\"name\":\"` + name + `\"}":{".":{},"f:name":{},"f:value":{}}`
+	}
+	return `{"f:spec":{"f:template":{"f:spec":{"f:containers":{` +
+		`"k:{\"name\":\"edp-admin-console\"}":{".":{},"f:name":{},"f:env":{` + entries + `}}}}}}}`
+}
+
+func TestGetAppliedEnv(t *testing.T) {
+	g := NewWithT(t)
+
+	managedFields := []metav1.ManagedFieldsEntry{
+		{
+			Manager:   EnvFieldManager,
+			Operation: metav1.ManagedFieldsOperationApply,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(containerFields("PG_HOST", "KEYCLOAK_URL"))},
+		},
+		{
+			Manager:   "manager",
+			Operation: metav1.ManagedFieldsOperationUpdate,
+			FieldsV1:  &metav1.FieldsV1{Raw: []byte(containerFields("HOST"))},
+		},
+	}
+
+	g.Expect(GetAppliedEnv(managedFields, "edp-admin-console")).Should(Equal([]string{"KEYCLOAK_URL", "PG_HOST"}))
+	g.Expect(GetAppliedEnv(managedFields, "other")).Should(BeEmpty())
+}
+
+func TestGetKeptEnv(t *testing.T) {
+	g := NewWithT(t)
+
+	template := coreV1Api.PodTemplateSpec{Spec: coreV1Api.PodSpec{Containers: []coreV1Api.Container{{
+		Name: "edp-admin-console",
+		Env:  []coreV1Api.EnvVar{{Name: "PLATFORM_TYPE"}, {Name: "PG_HOST"}},
+	}}}}
+	legacy := metav1.ManagedFieldsEntry{
+		Manager:   "edp-admin-console-operator",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(containerFields("PLATFORM_TYPE", "PG_HOST"))},
+	}
+	applied := metav1.ManagedFieldsEntry{
+		Manager:   EnvFieldManager,
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(containerFields("KEYCLOAK_URL"))},
+	}
+
+	g.Expect(GetKeptEnv([]metav1.ManagedFieldsEntry{legacy}, template, "edp-admin-console")).Should(Equal([]string{"PLATFORM_TYPE", "PG_HOST"}))
+	g.Expect(GetKeptEnv([]metav1.ManagedFieldsEntry{legacy, applied}, template, "edp-admin-console")).Should(Equal([]string{"KEYCLOAK_URL"}))
+}
+
+func TestGenerateEnvMigrationPatch(t *testing.T) {
+	config := WorkloadConfig{
+		Env:           []coreV1Api.EnvVar{{Name: "PG_HOST", Value: "edp-db"}},
+		ConfigMapName: "edp-admin-console",
+		Checksum:      "abc",
+	}
+
+	legacy := metav1.ManagedFieldsEntry{
+		Manager:   "edp-admin-console-operator",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{},"f:template":{` +
+			`"f:metadata":{"f:annotations":{".":{},"f:v2.edp.epam.com/config-checksum":{}}},` +
+			`"f:spec":{"f:containers":{"k:{\"name\":\"edp-admin-console\"}":{".":{},"f:name":{},"f:envFrom":{},"f:env":{` +
+			`"k:{\"name\":\"PG_HOST\"}":{".":{},"f:name":{},"f:value":{}},` +
+			`"k:{\"name\":\"OBSOLETE\"}":{".":{},"f:name":{},"f:value":{}}}}}}}}}`)},
+	}
+	helm := metav1.ManagedFieldsEntry{
+		Manager:   "helm",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{` +
+			`"k:{\"name\":\"edp-admin-console\"}":{"f:env":{"k:{\"name\":\"PG_HOST\"}":{".":{},"f:name":{},"f:value":{}}}}}}}}}`)},
+	}
+	unrelated := metav1.ManagedFieldsEntry{
+		Manager:   "kube-controller-manager",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:replicas":{}}}`)},
+	}
+	applied := metav1.ManagedFieldsEntry{
+		Manager:   EnvFieldManager,
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(containerFields("PG_HOST"))},
+	}
+
+	t.Run("fields released from the other managers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		patch, err := GenerateEnvMigrationPatch(metav1.ObjectMeta{
+			ResourceVersion: "42",
+			ManagedFields:   []metav1.ManagedFieldsEntry{legacy, helm, unrelated},
+		}, "edp-admin-console", config)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		var ops []struct {
+			Op    string          `json:"op"`
+			Path  string          `json:"path"`
+			Value json.RawMessage `json:"value"`
+		}
+		g.Expect(json.Unmarshal(patch, &ops)).Should(Succeed())
+		g.Expect(ops).Should(HaveLen(2))
+		g.Expect(ops[0].Op).Should(Equal("test"))
+		g.Expect(string(ops[0].Value)).Should(Equal(`"42"`))
+		g.Expect(ops[1].Path).Should(Equal("/metadata/managedFields"))
+
+		var managedFields []metav1.ManagedFieldsEntry
+		g.Expect(json.Unmarshal(ops[1].Value, &managedFields)).Should(Succeed())
+		g.Expect(managedFields).Should(HaveLen(2))
+		g.Expect(managedFields[0].Manager).Should(Equal(legacy.Manager))
+		g.Expect(managedFields[1]).Should(Equal(unrelated))
+
+		fields, err := decodeFields(managedFields[0])
+		g.Expect(err).ShouldNot(HaveOccurred())
+		_, ok := lookupFields(fields, "f:spec", "f:replicas")
+		g.Expect(ok).Should(BeTrue())
+		env, _ := lookupFields(fields, containerFieldPath("edp-admin-console", "f:env")...)
+		g.Expect(env).Should(HaveKey(nameKey("OBSOLETE")))
+		g.Expect(env).ShouldNot(HaveKey(nameKey("PG_HOST")))
+		container, _ := lookupFields(fields, containerFieldPath("edp-admin-console")...)
+		g.Expect(container).ShouldNot(HaveKey("f:envFrom"))
+		annotations, _ := lookupFields(fields, "f:spec", "f:template", "f:metadata", "f:annotations")
+		g.Expect(annotations).Should(Equal(map[string]interface{}{".": map[string]interface{}{}}))
+	})
+
+	t.Run("configuration applied before", func(t *testing.T) {
+		g := NewWithT(t)
+
+		patch, err := GenerateEnvMigrationPatch(metav1.ObjectMeta{
+			ManagedFields: []metav1.ManagedFieldsEntry{legacy, applied},
+		}, "edp-admin-console", config)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(patch).Should(BeNil())
+	})
+
+	t.Run("fields owned by no other manager", func(t *testing.T) {
+		g := NewWithT(t)
+
+		patch, err := GenerateEnvMigrationPatch(metav1.ObjectMeta{
+			ManagedFields: []metav1.ManagedFieldsEntry{unrelated},
+		}, "edp-admin-console", config)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(patch).Should(BeNil())
+	})
+}
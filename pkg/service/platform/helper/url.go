@@ -0,0 +1,158 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	networkingV1Api "k8s.io/api/networking/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+// ExposureNotFoundError is returned when the object exposing the admin console does not exist (yet).
+type ExposureNotFoundError struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (e *ExposureNotFoundError) Error() string {
+	return fmt.Sprintf("%s %s/%s exposing the admin console is not found", e.Kind, e.Namespace, e.Name)
+}
+
+// NoHostError is returned when the object exposing the admin console defines no host it is reachable at.
+type NoHostError struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (e *NoHostError) Error() string {
+	return fmt.Sprintf("%s %s/%s defines no host for the admin console", e.Kind, e.Namespace, e.Name)
+}
+
+// IsExposureNotFound reports whether the error, or any error it wraps, is an ExposureNotFoundError.
+func IsExposureNotFound(err error) bool {
+	var target *ExposureNotFoundError
+	return errors.As(err, &target)
+}
+
+// IsNoHost reports whether the error, or any error it wraps, is a NoHostError.
+func IsNoHost(err error) bool {
+	var target *NoHostError
+	return errors.As(err, &target)
+}
+
+// GenerateUrl joins the scheme, the host and the path into the admin console URL. Trailing
+// slashes are trimmed from the path, so the root path results in a URL without a path.
+func GenerateUrl(scheme, host, path string) string {
+	return fmt.Sprintf("%s://%s%s", scheme, host, strings.TrimRight(path, UrlCutset))
+}
+
+// GetIngressUrl returns the admin console URL exposed by the Ingress. The rule routing to the admin
+// console Service is preferred, the one for the expected host first. An Ingress with only a default
+// backend is reached at the address of its load balancer. The scheme is https when spec.tls covers the host.
+func GetIngressUrl(ingress *networkingV1Api.Ingress, ac adminConsoleApi.AdminConsole) (string, error) {
+	host, path, found := selectIngressRule(ingress, ac)
+	if !found {
+		host = getLoadBalancerHost(ingress)
+	}
+
+	if host == "" {
+		return "", &NoHostError{Kind: "Ingress", Namespace: ingress.Namespace, Name: ingress.Name}
+	}
+
+	scheme := "http"
+	if ingressTLSCovers(ingress.Spec.TLS, host) {
+		scheme = "https"
+	}
+
+	return GenerateUrl(scheme, host, path), nil
+}
+
+// selectIngressRule returns the host and the path of the best matching rule: a rule for the expected host
+// routing to the admin console Service, then any rule routing to it, then the first rule with a host.
+// Rules without HTTP paths are served under spec.basePath.
+func selectIngressRule(ingress *networkingV1Api.Ingress, ac adminConsoleApi.AdminConsole) (string, string, bool) {
+	expectedHost, expectedPath := GetHost(ac), GetPath(ac)
+
+	var (
+		host, path string
+		rank       int
+	)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" {
+			continue
+		}
+
+		rulePath, servesAdminConsole := selectIngressPath(rule, ac.Name, expectedPath)
+		r := 1
+		if servesAdminConsole {
+			r = 2
+			if rule.Host == expectedHost {
+				r = 3
+			}
+		}
+
+		if r > rank {
+			host, path, rank = rule.Host, rulePath, r
+		}
+	}
+
+	return host, path, rank > 0
+}
+
+// selectIngressPath returns the path of the rule routing to the service, preferring the expected path,
+// and whether the rule routes to the service at all.
+func selectIngressPath(rule networkingV1Api.IngressRule, service, expectedPath string) (string, bool) {
+	if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+		return expectedPath, false
+	}
+
+	path, found := "", false
+	for _, p := range rule.HTTP.Paths {
+		if p.Backend.Service == nil || p.Backend.Service.Name != service {
+			continue
+		}
+		if p.Path == expectedPath {
+			return p.Path, true
+		}
+		if !found {
+			path, found = p.Path, true
+		}
+	}
+
+	if !found {
+		return rule.HTTP.Paths[0].Path, false
+	}
+	return path, true
+}
+
+// getLoadBalancerHost returns the host name or, if there is none, the IP of the Ingress load balancer.
+func getLoadBalancerHost(ingress *networkingV1Api.Ingress) string {
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.Hostname != "" {
+			return lb.Hostname
+		}
+		if lb.IP != "" {
+			return lb.IP
+		}
+	}
+	return ""
+}
+
+// ingressTLSCovers reports whether a TLS entry applies to the host. An entry without hosts applies to any host.
+func ingressTLSCovers(tls []networkingV1Api.IngressTLS, host string) bool {
+	for _, t := range tls {
+		if len(t.Hosts) == 0 {
+			return true
+		}
+		for _, h := range t.Hosts {
+			if hostMatches(h, host) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package helper
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	coreV1Api "k8s.io/api/core/v1"
+	networkingV1Api "k8s.io/api/networking/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+func ingressRule(host string, paths ...networkingV1Api.HTTPIngressPath) networkingV1Api.IngressRule {
+	rule := networkingV1Api.IngressRule{Host: host}
+	if len(paths) > 0 {
+		rule.HTTP = &networkingV1Api.HTTPIngressRuleValue{Paths: paths}
+	}
+	return rule
+}
+
+func ingressPath(path, service string) networkingV1Api.HTTPIngressPath {
+	return networkingV1Api.HTTPIngressPath{
+		Path:    path,
+		Backend: networkingV1Api.IngressBackend{Service: &networkingV1Api.IngressServiceBackend{Name: service}},
+	}
+}
+
+func TestGetIngressUrl(t *testing.T) {
+	const host = "edp-admin-console-edp.example.com"
+
+	loadBalancer := networkingV1Api.IngressStatus{LoadBalancer: coreV1Api.LoadBalancerStatus{
+		Ingress: []coreV1Api.LoadBalancerIngress{{IP: "10.0.0.1"}},
+	}}
+
+	tests := []struct {
+		name     string
+		basePath string
+		spec     networkingV1Api.IngressSpec
+		status   networkingV1Api.IngressStatus
+		want     string
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name:   "default backend only",
+			spec:   networkingV1Api.IngressSpec{DefaultBackend: &networkingV1Api.IngressBackend{}},
+			status: loadBalancer,
+			want:   "http://10.0.0.1",
+		},
+		{
+			name: "plain http",
+			spec: networkingV1Api.IngressSpec{Rules: []networkingV1Api.IngressRule{
+				ingressRule(host, ingressPath("/", "edp-admin-console")),
+			}},
+			want: "http://" + host,
+		},
+		{
+			name: "tls host",
+			spec: networkingV1Api.IngressSpec{
+				Rules: []networkingV1Api.IngressRule{ingressRule(host, ingressPath("/", "edp-admin-console"))},
+				TLS:   []networkingV1Api.IngressTLS{{Hosts: []string{host}}},
+			},
+			want: "https://" + host,
+		},
+		{
+			name: "tls for another host",
+			spec: networkingV1Api.IngressSpec{
+				Rules: []networkingV1Api.IngressRule{ingressRule(host, ingressPath("/", "edp-admin-console"))},
+				TLS:   []networkingV1Api.IngressTLS{{Hosts: []string{"other.example.com"}}},
+			},
+			want: "http://" + host,
+		},
+		{
+			name:     "path other than the root",
+			basePath: "console",
+			spec: networkingV1Api.IngressSpec{Rules: []networkingV1Api.IngressRule{
+				ingressRule("example.com", ingressPath("/other", "edp-admin-console"), ingressPath("/console", "edp-admin-console")),
+			}},
+			want: "http://example.com/console",
+		},
+		{
+			name: "rule of the admin console service preferred",
+			spec: networkingV1Api.IngressSpec{Rules: []networkingV1Api.IngressRule{
+				ingressRule("sonar.example.com", ingressPath("/", "sonar")),
+				ingressRule("console.example.com", ingressPath("/", "edp-admin-console")),
+			}},
+			want: "http://console.example.com",
+		},
+		{
+			name: "rule without paths",
+			spec: networkingV1Api.IngressSpec{Rules: []networkingV1Api.IngressRule{ingressRule(host)}},
+			want: "http://" + host,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ingress := &networkingV1Api.Ingress{Spec: tt.spec, Status: tt.status}
+			url, err := GetIngressUrl(ingress, testAdminConsole(adminConsoleApi.AdminConsoleSpec{BasePath: tt.basePath}))
+			if tt.want == "" {
+				g.Expect(IsNoHost(err)).Should(BeTrue())
+				return
+			}
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(url).Should(Equal(tt.want))
+		})
+	}
+}
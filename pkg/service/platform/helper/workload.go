@@ -0,0 +1,278 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+
+	coreV1Api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	adminConsoleSpec "github.com/epam/edp-admin-console-operator/v2/pkg/service/admin_console/spec"
+)
+
+// GetImage returns the admin console image reference built from the CR spec.
+func GetImage(ac adminConsoleApi.AdminConsole) string {
+	image, version := ac.Spec.Image, ac.Spec.Version
+	if image == "" {
+		image = adminConsoleSpec.DefaultImage
+	}
+	if version == "" {
+		version = adminConsoleSpec.DefaultVersion
+	}
+	return fmt.Sprintf("%s:%s", image, version)
+}
+
+// GetReplicas returns the desired number of admin console pods. With autoscaling it is the lower
+// autoscaling bound, the number the workload starts with.
+func GetReplicas(ac adminConsoleApi.AdminConsole) int32 {
+	if IsAutoscaled(ac) {
+		return GetMinReplicas(ac)
+	}
+
+	if ac.Spec.Replicas == nil {
+		return adminConsoleSpec.DefaultReplicas
+	}
+	return *ac.Spec.Replicas
+}
+
+// GetWorkloadReplicas returns the number of replicas to set on the existing workload. The current
+// number is kept with autoscaling, so the operator does not fight the HorizontalPodAutoscaler.
+func GetWorkloadReplicas(ac adminConsoleApi.AdminConsole, current *int32) int32 {
+	if IsAutoscaled(ac) && current != nil {
+		return *current
+	}
+	return GetReplicas(ac)
+}
+
+// GetPort returns the port the admin console listens on.
+func GetPort(ac adminConsoleApi.AdminConsole) int32 {
+	if ac.Spec.Port == 0 {
+		return adminConsoleSpec.AdminConsolePort
+	}
+	return ac.Spec.Port
+}
+
+// GetHost returns the external host name of the admin console.
+func GetHost(ac adminConsoleApi.AdminConsole) string {
+	if ac.Spec.BasePath != "" {
+		return ac.Spec.EdpSpec.DnsWildcard
+	}
+
+	return fmt.Sprintf("%s-%s.%s", ac.Name, GetEdpName(ac), ac.Spec.EdpSpec.DnsWildcard)
+}
+
+// GetEdpName returns the EDP tenant name, the namespace of the CR by default.
+func GetEdpName(ac adminConsoleApi.AdminConsole) string {
+	if ac.Spec.EdpSpec.Name == "" {
+		return ac.Namespace
+	}
+	return ac.Spec.EdpSpec.Name
+}
+
+// GetPath returns the external path of the admin console, always starting with a slash.
+func GetPath(ac adminConsoleApi.AdminConsole) string {
+	return "/" + strings.Trim(ac.Spec.BasePath, "/")
+}
+
+// GenerateBaseEnv returns the environment variables derived from the CR spec and the platform type.
+// Additional variables from the spec take precedence over the generated ones. HOST depends on the
+// object exposing the admin console, it is generated during integration by GenerateEdpEnv.
+func GenerateBaseEnv(ac adminConsoleApi.AdminConsole, platformType string) []coreV1Api.EnvVar {
+	env := []coreV1Api.EnvVar{
+		{
+			Name: "NAMESPACE",
+			ValueFrom: &coreV1Api.EnvVarSource{
+				FieldRef: &coreV1Api.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "metadata.namespace",
+				},
+			},
+		},
+		{
+			Name:  "EDP_ADMIN_CONSOLE_VERSION",
+			Value: GetImageVersion(ac),
+		},
+		{
+			Name:  "DNS_WILDCARD",
+			Value: ac.Spec.EdpSpec.DnsWildcard,
+		},
+		{
+			Name:  "PLATFORM_TYPE",
+			Value: platformType,
+		},
+	}
+
+	if ac.Spec.BasePath != "" {
+		env = append(env, coreV1Api.EnvVar{
+			Name:  "BASE_PATH",
+			Value: GetPath(ac),
+		})
+	}
+
+	return UpdateEnv(env, ac.Spec.Env)
+}
+
+// GenerateEdpEnv returns the environment variables with the metadata of the EDP tenant the admin console belongs to
+// and HOST, the external URL of the admin console, unless spec.env sets it.
+func GenerateEdpEnv(ac adminConsoleApi.AdminConsole, url string) []coreV1Api.EnvVar {
+	var env []coreV1Api.EnvVar
+	if _, ok := findEnv(ac.Spec.Env, "HOST"); !ok {
+		env = append(env, coreV1Api.EnvVar{
+			Name:  "HOST",
+			Value: url,
+		})
+	}
+
+	env = append(env, coreV1Api.EnvVar{
+		Name:  "EDP_NAME",
+		Value: GetEdpName(ac),
+	})
+
+	if ac.Spec.EdpSpec.TestReportTools != "" {
+		env = append(env, coreV1Api.EnvVar{
+			Name:  "TEST_REPORT_TOOLS",
+			Value: ac.Spec.EdpSpec.TestReportTools,
+		})
+	}
+
+	return env
+}
+
+// GetImageVersion returns the admin console image tag.
+func GetImageVersion(ac adminConsoleApi.AdminConsole) string {
+	if ac.Spec.Version == "" {
+		return adminConsoleSpec.DefaultVersion
+	}
+	return ac.Spec.Version
+}
+
+// GeneratePodTemplate returns the admin console pod template. The container is named after the CR,
+// so it can be found later by SelectContainer.
+func GeneratePodTemplate(ac adminConsoleApi.AdminConsole, env []coreV1Api.EnvVar) coreV1Api.PodTemplateSpec {
+	var (
+		user                   int64 = adminConsoleSpec.SecurityContextUser
+		nonRoot                      = true
+		noPrivileges                 = false
+		readOnlyRoot                 = true
+		terminationGracePeriod int64 = 30
+		port                         = GetPort(ac)
+		probeHandler                 = coreV1Api.Handler{TCPSocket: &coreV1Api.TCPSocketAction{Port: intstr.FromInt(int(port))}}
+	)
+
+	volumes, volumeMounts := ac.Spec.Volumes, ac.Spec.VolumeMounts
+	if caVolumes, caMounts := dbCaVolume(ac); len(caVolumes) != 0 {
+		volumes = append(append([]coreV1Api.Volume{}, volumes...), caVolumes...)
+		volumeMounts = append(append([]coreV1Api.VolumeMount{}, volumeMounts...), caMounts...)
+	}
+
+	resources := *ac.Spec.Resources.DeepCopy()
+	if len(resources.Requests) == 0 && len(resources.Limits) == 0 {
+		resources.Requests = coreV1Api.ResourceList{
+			coreV1Api.ResourceMemory: resource.MustParse(adminConsoleSpec.MemoryRequest),
+		}
+	}
+
+	return coreV1Api.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: GenerateLabels(ac.Name),
+		},
+		Spec: coreV1Api.PodSpec{
+			ImagePullSecrets: ac.Spec.ImagePullSecrets,
+			Containers: []coreV1Api.Container{
+				{
+					Name:            ac.Name,
+					Image:           GetImage(ac),
+					ImagePullPolicy: coreV1Api.PullIfNotPresent,
+					Env:             env,
+					Ports: []coreV1Api.ContainerPort{
+						{
+							ContainerPort: port,
+							Protocol:      coreV1Api.ProtocolTCP,
+						},
+					},
+					LivenessProbe: &coreV1Api.Probe{
+						Handler:             probeHandler,
+						FailureThreshold:    5,
+						InitialDelaySeconds: 120,
+						PeriodSeconds:       20,
+						SuccessThreshold:    1,
+						TimeoutSeconds:      1,
+					},
+					ReadinessProbe: &coreV1Api.Probe{
+						Handler:             probeHandler,
+						FailureThreshold:    5,
+						InitialDelaySeconds: 20,
+						PeriodSeconds:       20,
+						SuccessThreshold:    1,
+						TimeoutSeconds:      1,
+					},
+					Resources: resources,
+					SecurityContext: &coreV1Api.SecurityContext{
+						AllowPrivilegeEscalation: &noPrivileges,
+						Privileged:               &noPrivileges,
+						ReadOnlyRootFilesystem:   &readOnlyRoot,
+					},
+					VolumeMounts:             volumeMounts,
+					TerminationMessagePath:   coreV1Api.TerminationMessagePathDefault,
+					TerminationMessagePolicy: coreV1Api.TerminationMessageReadFile,
+				},
+			},
+			DNSPolicy:     coreV1Api.DNSClusterFirst,
+			RestartPolicy: coreV1Api.RestartPolicyAlways,
+			SecurityContext: &coreV1Api.PodSecurityContext{
+				FSGroup:      &user,
+				RunAsGroup:   &user,
+				RunAsNonRoot: &nonRoot,
+				RunAsUser:    &user,
+			},
+			ServiceAccountName:            ac.Spec.ServiceAccountName,
+			TerminationGracePeriodSeconds: &terminationGracePeriod,
+			Volumes:                       volumes,
+			NodeSelector:                  ac.Spec.NodeSelector,
+			Affinity:                      ac.Spec.Affinity,
+			Tolerations:                   ac.Spec.Tolerations,
+		},
+	}
+}
+
+// MergePodTemplate applies the desired pod template onto the existing one. The env of the admin console container
+// is rebuilt from the desired one, only the entries applied during integration, whose names are given, are kept
+// together with the ConfigMap reference. Containers added by other actors (e.g. injected sidecars) and annotations
+// set by them (e.g. kubectl rollout restart) are preserved.
+func MergePodTemplate(existing *coreV1Api.PodTemplateSpec, desired coreV1Api.PodTemplateSpec, containerName string, applied []string) {
+	if current, err := SelectContainer(existing.Spec.Containers, containerName); err == nil {
+		for i := range desired.Spec.Containers {
+			if desired.Spec.Containers[i].Name == containerName {
+				desired.Spec.Containers[i].Env = mergeEnv(current.Env, desired.Spec.Containers[i].Env, applied)
+				desired.Spec.Containers[i].EnvFrom = current.EnvFrom
+			}
+		}
+	}
+
+	for _, c := range existing.Spec.Containers {
+		if _, err := SelectContainer(desired.Spec.Containers, c.Name); err != nil {
+			desired.Spec.Containers = append(desired.Spec.Containers, c)
+		}
+	}
+
+	annotations := existing.Annotations
+	*existing = desired
+	if len(annotations) != 0 {
+		existing.Annotations = annotations
+	}
+}
+
+// mergeEnv returns the desired env together with the applied entries of the current env, in the current order,
+// so an unchanged env does not roll the pods out. Entries neither desired nor applied are dropped.
+func mergeEnv(current, desired []coreV1Api.EnvVar, applied []string) []coreV1Api.EnvVar {
+	var kept []coreV1Api.EnvVar
+	for _, e := range current {
+		if _, ok := findEnv(desired, e.Name); ok || StringInSlice(e.Name, applied) {
+			kept = append(kept, e)
+		}
+	}
+	return UpdateEnv(kept, desired)
+}
@@ -0,0 +1,121 @@
+package helper
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	coreV1Api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+)
+
+func testAdminConsole(spec adminConsoleApi.AdminConsoleSpec) adminConsoleApi.AdminConsole {
+	spec.EdpSpec.DnsWildcard = "example.com"
+	return adminConsoleApi.AdminConsole{
+		ObjectMeta: metav1.ObjectMeta{Name: "edp-admin-console", Namespace: "edp"},
+		Spec:       spec,
+	}
+}
+
+func envNames(env []coreV1Api.EnvVar) []string {
+	var names []string
+	for _, e := range env {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+func TestMergePodTemplate(t *testing.T) {
+	const name = "edp-admin-console"
+
+	sidecar := coreV1Api.Container{Name: "istio-proxy", Image: "istio/proxyv2"}
+	existingTemplate := func(ac adminConsoleApi.AdminConsole, env ...coreV1Api.EnvVar) coreV1Api.PodTemplateSpec {
+		template := GeneratePodTemplate(ac, append(GenerateBaseEnv(ac, "kubernetes"), env...))
+		template.Annotations = map[string]string{RestartedAtAnnotation: "2022-06-01T00:00:00Z"}
+		return template
+	}
+
+	tests := []struct {
+		name       string
+		existing   coreV1Api.PodTemplateSpec
+		desired    adminConsoleApi.AdminConsole
+		applied    []string
+		wantEnv    []string
+		notWantEnv []string
+		wantValue  map[string]string
+		containers []string
+	}{
+		{
+			name: "env entry removed from spec.env",
+			existing: existingTemplate(testAdminConsole(adminConsoleApi.AdminConsoleSpec{
+				Env: []coreV1Api.EnvVar{{Name: "OBSOLETE", Value: "1"}, {Name: "KEPT", Value: "1"}},
+			})),
+			desired: testAdminConsole(adminConsoleApi.AdminConsoleSpec{
+				Env: []coreV1Api.EnvVar{{Name: "KEPT", Value: "2"}},
+			}),
+			wantEnv:    []string{"PLATFORM_TYPE", "KEPT"},
+			notWantEnv: []string{"OBSOLETE"},
+			wantValue:  map[string]string{"KEPT": "2"},
+			containers: []string{name},
+		},
+		{
+			name:       "basePath cleared",
+			existing:   existingTemplate(testAdminConsole(adminConsoleApi.AdminConsoleSpec{BasePath: "admin"})),
+			desired:    testAdminConsole(adminConsoleApi.AdminConsoleSpec{}),
+			wantEnv:    []string{"PLATFORM_TYPE"},
+			notWantEnv: []string{"BASE_PATH"},
+			containers: []string{name},
+		},
+		{
+			name: "entries applied during integration kept",
+			existing: existingTemplate(testAdminConsole(adminConsoleApi.AdminConsoleSpec{}),
+				coreV1Api.EnvVar{Name: "PG_HOST", Value: "db"}, coreV1Api.EnvVar{Name: "DB_HOST", Value: "db"}),
+			desired:    testAdminConsole(adminConsoleApi.AdminConsoleSpec{}),
+			applied:    []string{"PG_HOST"},
+			wantEnv:    []string{"PLATFORM_TYPE", "PG_HOST"},
+			notWantEnv: []string{"DB_HOST"},
+			wantValue:  map[string]string{"PG_HOST": "db"},
+			containers: []string{name},
+		},
+		{
+			name: "foreign containers preserved",
+			existing: func() coreV1Api.PodTemplateSpec {
+				template := existingTemplate(testAdminConsole(adminConsoleApi.AdminConsoleSpec{}))
+				template.Spec.Containers = append(template.Spec.Containers, sidecar)
+				return template
+			}(),
+			desired:    testAdminConsole(adminConsoleApi.AdminConsoleSpec{}),
+			wantEnv:    []string{"PLATFORM_TYPE"},
+			containers: []string{name, sidecar.Name},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			existing := tt.existing.DeepCopy()
+			MergePodTemplate(existing, GeneratePodTemplate(tt.desired, GenerateBaseEnv(tt.desired, "kubernetes")), name, tt.applied)
+
+			var containers []string
+			for _, c := range existing.Spec.Containers {
+				containers = append(containers, c.Name)
+			}
+			g.Expect(containers).Should(Equal(tt.containers))
+			g.Expect(existing.Annotations).Should(HaveKey(RestartedAtAnnotation))
+
+			container, err := SelectContainer(existing.Spec.Containers, name)
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(envNames(container.Env)).Should(ContainElements(tt.wantEnv))
+			for _, n := range tt.notWantEnv {
+				g.Expect(envNames(container.Env)).ShouldNot(ContainElement(n))
+			}
+			for n, v := range tt.wantValue {
+				e, ok := findEnv(container.Env, n)
+				g.Expect(ok).Should(BeTrue())
+				g.Expect(e.Value).Should(Equal(v))
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package platform
+
+import (
+	"context"
+	"time"
+
+	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
+	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/helper"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/metrics"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// instrumentedService bounds every call to the wrapped platform service with the call timeout
+// and records its latency.
+type instrumentedService struct {
+	service     PlatformService
+	callTimeout time.Duration
+}
+
+// call returns the context of a call to the wrapped service and the function to run when the call returns,
+// which records the duration of the call. err points to the named result of the call.
+func (s instrumentedService) call(ctx context.Context, method string, err *error) (context.Context, func()) {
+	start := time.Now()
+	cancel := func() {}
+	if s.callTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, s.callTimeout)
+	}
+
+	return ctx, func() {
+		cancel()
+		metrics.ObservePlatformCall(method, time.Since(start), *err)
+	}
+}
+
+func (s instrumentedService) CreateSecret(ctx context.Context, ac adminConsoleApi.AdminConsole, name string, data map[string][]byte) (err error) {
+	ctx, done := s.call(ctx, "CreateSecret", &err)
+	defer done()
+	return s.service.CreateSecret(ctx, ac, name, data)
+}
+
+func (s instrumentedService) PatchDeploymentEnv(ctx context.Context, ac adminConsoleApi.AdminConsole, config platformHelper.WorkloadConfig) (err error) {
+	ctx, done := s.call(ctx, "PatchDeploymentEnv", &err)
+	defer done()
+	return s.service.PatchDeploymentEnv(ctx, ac, config)
+}
+
+func (s instrumentedService) CreateOrUpdateConfigMap(ctx context.Context, ac adminConsoleApi.AdminConsole, data map[string]string) (err error) {
+	ctx, done := s.call(ctx, "CreateOrUpdateConfigMap", &err)
+	defer done()
+	return s.service.CreateOrUpdateConfigMap(ctx, ac, data)
+}
+
+func (s instrumentedService) DeleteConfigMap(ctx context.Context, ac adminConsoleApi.AdminConsole) (err error) {
+	ctx, done := s.call(ctx, "DeleteConfigMap", &err)
+	defer done()
+	return s.service.DeleteConfigMap(ctx, ac)
+}
+
+func (s instrumentedService) RestartDeployment(ctx context.Context, ac adminConsoleApi.AdminConsole) (err error) {
+	ctx, done := s.call(ctx, "RestartDeployment", &err)
+	defer done()
+	return s.service.RestartDeployment(ctx, ac)
+}
+
+func (s instrumentedService) UpdateAdminConsole(ctx context.Context, ac adminConsoleApi.AdminConsole) (result *adminConsoleApi.AdminConsole, err error) {
+	ctx, done := s.call(ctx, "UpdateAdminConsole", &err)
+	defer done()
+	return s.service.UpdateAdminConsole(ctx, ac)
+}
+
+func (s instrumentedService) GetKeycloakClient(ctx context.Context, name string, namespace string) (result keycloakV1Api.KeycloakClient, err error) {
+	ctx, done := s.call(ctx, "GetKeycloakClient", &err)
+	defer done()
+	return s.service.GetKeycloakClient(ctx, name, namespace)
+}
+
+func (s instrumentedService) CreateOrUpdateKeycloakClient(ctx context.Context, ac adminConsoleApi.AdminConsole, kc *keycloakV1Api.KeycloakClient) (err error) {
+	ctx, done := s.call(ctx, "CreateOrUpdateKeycloakClient", &err)
+	defer done()
+	return s.service.CreateOrUpdateKeycloakClient(ctx, ac, kc)
+}
+
+func (s instrumentedService) GetExternalUrl(ctx context.Context, ac adminConsoleApi.AdminConsole) (result string, err error) {
+	ctx, done := s.call(ctx, "GetExternalUrl", &err)
+	defer done()
+	return s.service.GetExternalUrl(ctx, ac)
+}
+
+func (s instrumentedService) GetDeploymentReadiness(ctx context.Context, instance adminConsoleApi.AdminConsole) (result *helper.Readiness, err error) {
+	ctx, done := s.call(ctx, "GetDeploymentReadiness", &err)
+	defer done()
+	return s.service.GetDeploymentReadiness(ctx, instance)
+}
+
+func (s instrumentedService) CreateEDPComponentIfNotExist(ctx context.Context, instance adminConsoleApi.AdminConsole, url string, icon string) (err error) {
+	ctx, done := s.call(ctx, "CreateEDPComponentIfNotExist", &err)
+	defer done()
+	return s.service.CreateEDPComponentIfNotExist(ctx, instance, url, icon)
+}
+
+func (s instrumentedService) CreateOrUpdateDeployment(ctx context.Context, ac adminConsoleApi.AdminConsole) (err error) {
+	ctx, done := s.call(ctx, "CreateOrUpdateDeployment", &err)
+	defer done()
+	return s.service.CreateOrUpdateDeployment(ctx, ac)
+}
+
+func (s instrumentedService) CreateOrUpdateService(ctx context.Context, ac adminConsoleApi.AdminConsole) (err error) {
+	ctx, done := s.call(ctx, "CreateOrUpdateService", &err)
+	defer done()
+	return s.service.CreateOrUpdateService(ctx, ac)
+}
+
+func (s instrumentedService) CreateOrUpdateAvailability(ctx context.Context, ac adminConsoleApi.AdminConsole) (err error) {
+	ctx, done := s.call(ctx, "CreateOrUpdateAvailability", &err)
+	defer done()
+	return s.service.CreateOrUpdateAvailability(ctx, ac)
+}
+
+func (s instrumentedService) CreateOrUpdateExposure(ctx context.Context, ac adminConsoleApi.AdminConsole) (err error) {
+	ctx, done := s.call(ctx, "CreateOrUpdateExposure", &err)
+	defer done()
+	return s.service.CreateOrUpdateExposure(ctx, ac)
+}
+
+func (s instrumentedService) DeleteSecret(ctx context.Context, ac adminConsoleApi.AdminConsole, name string) (err error) {
+	ctx, done := s.call(ctx, "DeleteSecret", &err)
+	defer done()
+	return s.service.DeleteSecret(ctx, ac, name)
+}
+
+func (s instrumentedService) GetSecretData(ctx context.Context, namespace string, name string) (result map[string][]byte, err error) {
+	ctx, done := s.call(ctx, "GetSecretData", &err)
+	defer done()
+	return s.service.GetSecretData(ctx, namespace, name)
+}
+
+func (s instrumentedService) PatchSecretData(ctx context.Context, namespace string, name string, data map[string][]byte) (err error) {
+	ctx, done := s.call(ctx, "PatchSecretData", &err)
+	defer done()
+	return s.service.PatchSecretData(ctx, namespace, name, data)
+}
+
+func (s instrumentedService) ReleaseSecret(ctx context.Context, ac adminConsoleApi.AdminConsole, name string) (err error) {
+	ctx, done := s.call(ctx, "ReleaseSecret", &err)
+	defer done()
+	return s.service.ReleaseSecret(ctx, ac, name)
+}
+
+func (s instrumentedService) DeleteKeycloakClient(ctx context.Context, name string, namespace string) (result bool, err error) {
+	ctx, done := s.call(ctx, "DeleteKeycloakClient", &err)
+	defer done()
+	return s.service.DeleteKeycloakClient(ctx, name, namespace)
+}
+
+func (s instrumentedService) DeleteEDPComponent(ctx context.Context, ac adminConsoleApi.AdminConsole) (err error) {
+	ctx, done := s.call(ctx, "DeleteEDPComponent", &err)
+	defer done()
+	return s.service.DeleteEDPComponent(ctx, ac)
+}
+
+func (s instrumentedService) ListEDPComponents(ctx context.Context, namespace string) (result []edpCompApi.EDPComponent, err error) {
+	ctx, done := s.call(ctx, "ListEDPComponents", &err)
+	defer done()
+	return s.service.ListEDPComponents(ctx, namespace)
+}
+
+func (s instrumentedService) ReleaseEDPComponent(ctx context.Context, ac adminConsoleApi.AdminConsole) (err error) {
+	ctx, done := s.call(ctx, "ReleaseEDPComponent", &err)
+	defer done()
+	return s.service.ReleaseEDPComponent(ctx, ac)
+}
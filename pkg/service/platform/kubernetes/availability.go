@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	autoscalingV2beta2Api "k8s.io/api/autoscaling/v2beta2"
+	policyV1beta1Api "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// CreateOrUpdateAvailability reconciles the HorizontalPodAutoscaler and the PodDisruptionBudget of the admin console Deployment.
+func (service K8SService) CreateOrUpdateAvailability(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	return service.ReconcileAvailability(ctx, ac, autoscalingV2beta2Api.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       ac.Name,
+	})
+}
+
+// ReconcileAvailability creates or updates the HorizontalPodAutoscaler scaling the target and the PodDisruptionBudget
+// of the admin console pods as configured in spec.availability, and removes them when they are no longer configured.
+func (service K8SService) ReconcileAvailability(ctx context.Context, ac adminConsoleApi.AdminConsole, target autoscalingV2beta2Api.CrossVersionObjectReference) error {
+	hpa := &autoscalingV2beta2Api.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: ac.Name, Namespace: ac.Namespace},
+	}
+	if platformHelper.IsAutoscaled(ac) {
+		res, err := controllerutil.CreateOrUpdate(ctx, service.client, hpa, func() error {
+			hpa.Labels = platformHelper.GenerateLabels(ac.Name)
+			hpa.Spec = platformHelper.GenerateHPASpec(ac, target)
+			return controllerutil.SetControllerReference(&ac, hpa, service.Scheme)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to reconcile horizontal pod autoscaler %s/%s", ac.Namespace, ac.Name)
+		}
+		log.V(1).Info("HorizontalPodAutoscaler has been reconciled", "Namespace", ac.Namespace, "Name", ac.Name, "result", res)
+	} else if err := service.deleteOwned(ctx, ac, hpa); err != nil {
+		return errors.Wrapf(err, "failed to delete horizontal pod autoscaler %s/%s", ac.Namespace, ac.Name)
+	}
+
+	pdb := &policyV1beta1Api.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: ac.Name, Namespace: ac.Namespace},
+	}
+	if platformHelper.HasDisruptionBudget(ac) {
+		res, err := controllerutil.CreateOrUpdate(ctx, service.client, pdb, func() error {
+			pdb.Labels = platformHelper.GenerateLabels(ac.Name)
+			pdb.Spec = platformHelper.GeneratePDBSpec(ac)
+			return controllerutil.SetControllerReference(&ac, pdb, service.Scheme)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to reconcile pod disruption budget %s/%s", ac.Namespace, ac.Name)
+		}
+		log.V(1).Info("PodDisruptionBudget has been reconciled", "Namespace", ac.Namespace, "Name", ac.Name, "result", res)
+	} else if err := service.deleteOwned(ctx, ac, pdb); err != nil {
+		return errors.Wrapf(err, "failed to delete pod disruption budget %s/%s", ac.Namespace, ac.Name)
+	}
+
+	return nil
+}
+
+// deleteOwned deletes the object if it exists and is controlled by the admin console.
+func (service K8SService) deleteOwned(ctx context.Context, ac adminConsoleApi.AdminConsole, obj client.Object) error {
+	if err := service.client.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if ref := metav1.GetControllerOf(obj); ref == nil || ref.UID != ac.UID {
+		return nil
+	}
+
+	if err := service.client.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// createOrUpdateHTTPRoute reconciles the Gateway API HTTPRoute which exposes the admin console outside the cluster.
+// Nothing is created when the spec points to an HTTPRoute managed outside the operator.
+func (service K8SService) createOrUpdateHTTPRoute(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	if !platformHelper.IsHTTPRouteManaged(ac) {
+		return nil
+	}
+
+	route := platformHelper.NewUnstructured(platformHelper.HTTPRouteGVK, ac.Namespace, ac.Name)
+	res, err := controllerutil.CreateOrUpdate(ctx, service.client, route, func() error {
+		route.SetLabels(platformHelper.GenerateLabels(ac.Name))
+		route.SetAnnotations(ac.Spec.Ingress.Annotations)
+		if err := unstructured.SetNestedField(route.Object, platformHelper.GenerateHTTPRouteSpec(ac), "spec"); err != nil {
+			return err
+		}
+
+		return controllerutil.SetControllerReference(&ac, route, service.Scheme)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to reconcile HTTPRoute %s/%s", ac.Namespace, ac.Name)
+	}
+
+	log.V(1).Info("HTTPRoute has been reconciled", "Namespace", ac.Namespace, "Name", ac.Name, "result", res)
+	return nil
+}
+
+// getHTTPRouteUrl reads the admin console URL from the HTTPRoute and the listener of the Gateway it is attached to.
+func (service K8SService) getHTTPRouteUrl(ctx context.Context, ac adminConsoleApi.AdminConsole) (string, error) {
+	name := platformHelper.GetHTTPRouteName(ac)
+	route := platformHelper.NewUnstructured(platformHelper.HTTPRouteGVK, ac.Namespace, name)
+	if err := service.client.Get(ctx, types.NamespacedName{Namespace: ac.Namespace, Name: name}, route); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", &platformHelper.ExposureNotFoundError{Kind: platformHelper.HTTPRouteGVK.Kind, Namespace: ac.Namespace, Name: name}
+		}
+		return "", errors.Wrapf(err, "failed to get HTTPRoute %s/%s", ac.Namespace, name)
+	}
+
+	parent, err := platformHelper.GetHTTPRouteParent(route)
+	if err != nil {
+		return "", err
+	}
+
+	gateway := platformHelper.NewUnstructured(platformHelper.GatewayGVK, parent.Namespace, parent.Name)
+	if err := service.client.Get(ctx, types.NamespacedName{Namespace: parent.Namespace, Name: parent.Name}, gateway); err != nil {
+		return "", errors.Wrapf(err, "failed to get Gateway %s/%s", parent.Namespace, parent.Name)
+	}
+
+	return platformHelper.GenerateHTTPRouteUrl(route, gateway, parent.SectionName)
+}
@@ -4,17 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
+	"time"
 
 	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
 	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
 	"github.com/pkg/errors"
+	appsV1Api "k8s.io/api/apps/v1"
 	coreV1Api "k8s.io/api/core/v1"
+	networkingV1Api "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	appsV1Client "k8s.io/client-go/kubernetes/typed/apps/v1"
 	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
 	networkingV1Client "k8s.io/client-go/kubernetes/typed/networking/v1"
@@ -31,6 +34,8 @@ import (
 
 var log = ctrl.Log.WithName("platform")
 
+const platformType = "kubernetes"
+
 type K8SService struct {
 	Scheme             *runtime.Scheme
 	CoreClient         coreV1Client.CoreV1Client
@@ -40,143 +45,219 @@ type K8SService struct {
 	AuthClient         authV1Client.RbacV1Client
 }
 
-func (service K8SService) GenerateDbSettings(ac adminConsoleApi.AdminConsole) ([]coreV1Api.EnvVar, error) {
-	if !ac.Spec.DbSpec.Enabled {
-		return []coreV1Api.EnvVar{
-			{
-				Name:  "DB_ENABLED",
-				Value: "false",
-			},
-		}, nil
+// PatchDeploymentEnv applies the configuration of the admin console container with server-side apply. The env entries
+// applied before and missing from config are removed, the entries set by other field managers are left alone.
+// Before the first apply the fields are taken over from the managers which set them so far, later changes made to them
+// by other managers are reported as conflicts instead of being overwritten.
+func (service K8SService) PatchDeploymentEnv(ctx context.Context, ac adminConsoleApi.AdminConsole, config platformHelper.WorkloadConfig) error {
+	d, err := service.AppsClient.Deployments(ac.Namespace).Get(ctx, ac.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get deployment %s/%s", ac.Namespace, ac.Name)
 	}
 
-	log.V(1).Info("Generating DB settings for Admin Console ",
-		"Namespace", ac.Namespace, "Name", ac.Name)
-	if platformHelper.ContainsEmptyString(ac.Spec.DbSpec.Name, ac.Spec.DbSpec.Hostname, ac.Spec.DbSpec.Port) {
-		return nil, errors.New("One or many DB settings field are empty!")
+	if _, err = platformHelper.SelectContainer(d.Spec.Template.Spec.Containers, ac.Name); err != nil {
+		return err
 	}
 
-	return []coreV1Api.EnvVar{
-		{
-			Name:  "PG_HOST",
-			Value: ac.Spec.DbSpec.Hostname,
-		},
-		{
-			Name:  "PG_PORT",
-			Value: ac.Spec.DbSpec.Port,
-		},
-		{
-			Name:  "PG_DATABASE",
-			Value: ac.Spec.DbSpec.Name,
-		},
-		{
-			Name:  "DB_ENABLED",
-			Value: strconv.FormatBool(ac.Spec.DbSpec.Enabled),
-		},
-	}, nil
+	migration, err := platformHelper.GenerateEnvMigrationPatch(d.ObjectMeta, ac.Name, config)
+	if err != nil {
+		return err
+	}
+	if migration != nil {
+		_, err = service.AppsClient.Deployments(ac.Namespace).Patch(ctx, ac.Name, types.JSONPatchType, migration, metav1.PatchOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to take env of deployment %s/%s over from its field managers", ac.Namespace, ac.Name)
+		}
+		log.Info("Env of deployment has been taken over from its field managers", "Namespace", ac.Namespace, "Name", ac.Name)
+	}
 
-}
+	patch, err := platformHelper.GenerateEnvApplyPatch(appsV1Api.SchemeGroupVersion.WithKind("Deployment"), ac, config)
+	if err != nil {
+		return err
+	}
 
-func (service K8SService) GenerateKeycloakSettings(ac adminConsoleApi.AdminConsole, keycloakUrl string) ([]coreV1Api.EnvVar, error) {
+	_, err = service.AppsClient.Deployments(ac.Namespace).Patch(ctx, ac.Name, types.ApplyPatchType, patch,
+		metav1.PatchOptions{FieldManager: platformHelper.EnvFieldManager})
+	if k8serrors.IsConflict(err) {
+		return errors.Wrapf(err, "env of deployment %s/%s is managed by another field manager", ac.Namespace, ac.Name)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to apply env of deployment %s/%s", ac.Namespace, ac.Name)
+	}
 
-	log.V(1).Info("Generating Keycloak settings for Admin Console",
-		"Namespace", ac.Namespace, "Name", ac.Name)
+	return nil
+}
 
-	if !ac.Spec.KeycloakSpec.Enabled {
-		return []coreV1Api.EnvVar{}, nil
+// RestartDeployment rolls the admin console pods out, so they pick up the changed secrets.
+func (service K8SService) RestartDeployment(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	patch, err := platformHelper.GenerateRestartPatch(time.Now())
+	if err != nil {
+		return err
 	}
 
-	return []coreV1Api.EnvVar{
-		{
-			Name: "KEYCLOAK_CLIENT_ID",
-			ValueFrom: &coreV1Api.EnvVarSource{
-				SecretKeyRef: &coreV1Api.SecretKeySelector{
-					LocalObjectReference: coreV1Api.LocalObjectReference{
-						Name: "admin-console-client",
-					},
-					Key: "username",
-				},
-			},
-		},
-		{
-			Name: "KEYCLOAK_CLIENT_SECRET",
-			ValueFrom: &coreV1Api.EnvVarSource{
-				SecretKeyRef: &coreV1Api.SecretKeySelector{
-					LocalObjectReference: coreV1Api.LocalObjectReference{
-						Name: "admin-console-client",
-					},
-					Key: "password",
-				},
-			},
-		},
-		{
-			Name:  "KEYCLOAK_URL",
-			Value: keycloakUrl,
-		},
-		{
-			Name:  "AUTH_KEYCLOAK_ENABLED",
-			Value: strconv.FormatBool(ac.Spec.KeycloakSpec.Enabled),
-		},
-	}, nil
+	_, err = service.AppsClient.Deployments(ac.Namespace).Patch(ctx, ac.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to restart deployment %s/%s", ac.Namespace, ac.Name)
+	}
+	return nil
 }
 
-func (service K8SService) PatchDeploymentEnv(ac adminConsoleApi.AdminConsole, env []coreV1Api.EnvVar) error {
-	if len(env) == 0 {
-		return nil
+// CreateOrUpdateDeployment reconciles the admin console Deployment with the CR spec.
+func (service K8SService) CreateOrUpdateDeployment(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	d := &appsV1Api.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ac.Name,
+			Namespace: ac.Namespace,
+		},
 	}
 
-	dc, err := service.AppsClient.Deployments(ac.Namespace).Get(context.TODO(), ac.Name, metav1.GetOptions{})
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			log.Info("Deployment not found!", "Namespace", ac.Namespace, "Name", ac.Name)
-			return nil
+	res, err := controllerutil.CreateOrUpdate(ctx, service.client, d, func() error {
+		replicas := platformHelper.GetWorkloadReplicas(ac, d.Spec.Replicas)
+		d.Labels = platformHelper.GenerateLabels(ac.Name)
+		d.Spec.Replicas = &replicas
+		if d.Spec.Selector == nil {
+			d.Spec.Selector = &metav1.LabelSelector{MatchLabels: platformHelper.GenerateLabels(ac.Name)}
 		}
-		return err
-	}
+		d.Spec.Strategy = generateStrategy(ac, replicas, d.Spec.Strategy)
+		platformHelper.MergePodTemplate(&d.Spec.Template,
+			platformHelper.GeneratePodTemplate(ac, platformHelper.GenerateBaseEnv(ac, platformType)), ac.Name,
+			platformHelper.GetKeptEnv(d.ManagedFields, d.Spec.Template, ac.Name))
 
-	container, err := platformHelper.SelectContainer(dc.Spec.Template.Spec.Containers, ac.Name)
+		return controllerutil.SetControllerReference(&ac, d, service.Scheme)
+	})
 	if err != nil {
-		return err
+		return errors.Wrapf(err, "failed to reconcile deployment %s/%s", ac.Namespace, ac.Name)
 	}
 
-	container.Env = platformHelper.UpdateEnv(container.Env, env)
+	log.V(1).Info("Deployment has been reconciled", "Namespace", ac.Namespace, "Name", ac.Name, "result", res)
+	return nil
+}
 
-	dc.Spec.Template.Spec.Containers = append(dc.Spec.Template.Spec.Containers, container)
+// generateStrategy returns the rollout strategy of the Deployment. The rolling update parameters already set,
+// e.g. the ones defaulted by the API server, are kept.
+func generateStrategy(ac adminConsoleApi.AdminConsole, replicas int32, current appsV1Api.DeploymentStrategy) appsV1Api.DeploymentStrategy {
+	if !platformHelper.UseRollingUpdate(ac, replicas) {
+		return appsV1Api.DeploymentStrategy{Type: appsV1Api.RecreateDeploymentStrategyType}
+	}
+	return appsV1Api.DeploymentStrategy{
+		Type:          appsV1Api.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: current.RollingUpdate,
+	}
+}
 
-	jsonDc, err := json.Marshal(dc)
+// CreateOrUpdateService reconciles the Service in front of the admin console pods.
+func (service K8SService) CreateOrUpdateService(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	svc := &coreV1Api.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ac.Name,
+			Namespace: ac.Namespace,
+		},
+	}
+
+	res, err := controllerutil.CreateOrUpdate(ctx, service.client, svc, func() error {
+		port := platformHelper.GetPort(ac)
+		svc.Labels = platformHelper.GenerateLabels(ac.Name)
+		svc.Spec.Type = coreV1Api.ServiceTypeClusterIP
+		svc.Spec.Selector = platformHelper.GenerateLabels(ac.Name)
+		svc.Spec.Ports = []coreV1Api.ServicePort{
+			{
+				Name:       "http",
+				Port:       port,
+				Protocol:   coreV1Api.ProtocolTCP,
+				TargetPort: intstr.FromInt(int(port)),
+			},
+		}
+
+		return controllerutil.SetControllerReference(&ac, svc, service.Scheme)
+	})
 	if err != nil {
-		return err
+		return errors.Wrapf(err, "failed to reconcile service %s/%s", ac.Namespace, ac.Name)
+	}
+
+	log.V(1).Info("Service has been reconciled", "Namespace", ac.Namespace, "Name", ac.Name, "result", res)
+	return nil
+}
+
+// CreateOrUpdateExposure reconciles the Ingress or the HTTPRoute which exposes the admin console outside the cluster.
+func (service K8SService) CreateOrUpdateExposure(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	switch platformHelper.GetExposure(ac, platformType) {
+	case adminConsoleApi.ExposureHTTPRoute:
+		return service.createOrUpdateHTTPRoute(ctx, ac)
+	case adminConsoleApi.ExposureRoute:
+		return platformHelper.NewPermanentError(
+			errors.Errorf("%s exposure is only supported on OpenShift", adminConsoleApi.ExposureRoute))
 	}
 
-	_, err = service.AppsClient.Deployments(dc.Namespace).Patch(context.TODO(), dc.Name, types.StrategicMergePatchType, jsonDc, metav1.PatchOptions{})
+	ingress := &networkingV1Api.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ac.Name,
+			Namespace: ac.Namespace,
+		},
+	}
+
+	res, err := controllerutil.CreateOrUpdate(ctx, service.client, ingress, func() error {
+		pathType := networkingV1Api.PathTypePrefix
+		ingress.Labels = platformHelper.GenerateLabels(ac.Name)
+		ingress.Annotations = ac.Spec.Ingress.Annotations
+		ingress.Spec.IngressClassName = ac.Spec.Ingress.IngressClassName
+		ingress.Spec.TLS = ac.Spec.Ingress.TLS
+		ingress.Spec.Rules = []networkingV1Api.IngressRule{
+			{
+				Host: platformHelper.GetHost(ac),
+				IngressRuleValue: networkingV1Api.IngressRuleValue{
+					HTTP: &networkingV1Api.HTTPIngressRuleValue{
+						Paths: []networkingV1Api.HTTPIngressPath{
+							{
+								Path:     platformHelper.GetPath(ac),
+								PathType: &pathType,
+								Backend: networkingV1Api.IngressBackend{
+									Service: &networkingV1Api.IngressServiceBackend{
+										Name: ac.Name,
+										Port: networkingV1Api.ServiceBackendPort{
+											Number: platformHelper.GetPort(ac),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		return controllerutil.SetControllerReference(&ac, ingress, service.Scheme)
+	})
 	if err != nil {
-		return err
+		return errors.Wrapf(err, "failed to reconcile ingress %s/%s", ac.Namespace, ac.Name)
 	}
 
-	return err
+	log.V(1).Info("Ingress has been reconciled", "Namespace", ac.Namespace, "Name", ac.Name, "result", res)
+	return nil
 }
 
-func (service K8SService) GetExternalUrl(namespace string, name string) (*string, error) {
-	ingress, err := service.NetworkingV1Client.Ingresses(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+// GetExternalUrl returns the admin console URL from the Ingress or the HTTPRoute which exposes it.
+func (service K8SService) GetExternalUrl(ctx context.Context, ac adminConsoleApi.AdminConsole) (string, error) {
+	if platformHelper.GetExposure(ac, platformType) == adminConsoleApi.ExposureHTTPRoute {
+		return service.getHTTPRouteUrl(ctx, ac)
+	}
+
+	ingress, err := service.NetworkingV1Client.Ingresses(ac.Namespace).Get(ctx, ac.Name, metav1.GetOptions{})
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
-			log.Info("Ingress not found", "Namespace", namespace, "Name", name)
-			return nil, nil
+			return "", &platformHelper.ExposureNotFoundError{Kind: "Ingress", Namespace: ac.Namespace, Name: ac.Name}
 		}
-		return nil, err
+		return "", errors.Wrapf(err, "failed to get ingress %s/%s", ac.Namespace, ac.Name)
 	}
 
-	routeScheme := "https"
-	u := fmt.Sprintf("%s://%s%s", routeScheme, ingress.Spec.Rules[0].Host, strings.TrimRight(ingress.Spec.Rules[0].HTTP.Paths[0].Path, platformHelper.UrlCutset))
-
-	return &u, nil
+	return platformHelper.GetIngressUrl(ingress, ac)
 }
 
-func (service K8SService) IsDeploymentReady(instance adminConsoleApi.AdminConsole) (bool, error) {
-	return helper.IsDeploymentReady(service.AppsClient, instance.Name, instance.Namespace)
+// GetDeploymentReadiness reports whether the admin console Deployment has rolled out to all desired replicas.
+func (service K8SService) GetDeploymentReadiness(ctx context.Context, instance adminConsoleApi.AdminConsole) (*helper.Readiness, error) {
+	return helper.GetDeploymentReadiness(ctx, service.AppsClient, &service.CoreClient, instance.Name, instance.Namespace)
 }
 
-func (service K8SService) CreateSecret(ac adminConsoleApi.AdminConsole, name string, data map[string][]byte) error {
+func (service K8SService) CreateSecret(ctx context.Context, ac adminConsoleApi.AdminConsole, name string, data map[string][]byte) error {
 	labels := platformHelper.GenerateLabels(ac.Name)
 
 	consoleSecretObject := &coreV1Api.Secret{
@@ -193,13 +274,13 @@ func (service K8SService) CreateSecret(ac adminConsoleApi.AdminConsole, name str
 		return err
 	}
 
-	_, err := service.CoreClient.Secrets(consoleSecretObject.Namespace).Get(context.TODO(), consoleSecretObject.Name, metav1.GetOptions{})
+	_, err := service.CoreClient.Secrets(consoleSecretObject.Namespace).Get(ctx, consoleSecretObject.Name, metav1.GetOptions{})
 
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			msg := fmt.Sprintf("Creating a new Secret %s/%s for Admin Console", consoleSecretObject.Namespace, consoleSecretObject.Name)
 			log.V(1).Info(msg)
-			consoleSecret, err := service.CoreClient.Secrets(consoleSecretObject.Namespace).Create(context.TODO(), consoleSecretObject, metav1.CreateOptions{})
+			consoleSecret, err := service.CoreClient.Secrets(consoleSecretObject.Namespace).Create(ctx, consoleSecretObject, metav1.CreateOptions{})
 			if err != nil {
 				return err
 			}
@@ -214,10 +295,120 @@ func (service K8SService) CreateSecret(ac adminConsoleApi.AdminConsole, name str
 	return nil
 }
 
-func (s K8SService) UpdateAdminConsole(ac adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error) {
-	if err := s.client.Update(context.TODO(), &ac); err != nil {
+// DeleteSecret deletes the secret generated for the admin console, a missing secret is not an error. The secret names
+// are shared by the admin consoles of the namespace, so a secret the admin console is not the controller of is kept.
+func (service K8SService) DeleteSecret(ctx context.Context, ac adminConsoleApi.AdminConsole, name string) error {
+	secret, err := service.CoreClient.Secrets(ac.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get secret %s/%s", ac.Namespace, name)
+	}
+
+	if !metav1.IsControlledBy(secret, &ac) {
+		log.Info("Secret is not controlled by the admin console, it is kept", "Namespace", ac.Namespace, "Name", name)
+		return nil
+	}
+
+	err = service.CoreClient.Secrets(ac.Namespace).Delete(ctx, name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &secret.UID},
+	})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete secret %s/%s", ac.Namespace, name)
+	}
+	log.Info("Secret has been deleted", "Namespace", ac.Namespace, "Name", name)
+	return nil
+}
+
+// CreateOrUpdateConfigMap reconciles the ConfigMap the generated settings of the admin console are rendered into.
+func (service K8SService) CreateOrUpdateConfigMap(ctx context.Context, ac adminConsoleApi.AdminConsole, data map[string]string) error {
+	cm := &coreV1Api.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      platformHelper.GetConfigMapName(ac),
+			Namespace: ac.Namespace,
+		},
+	}
+
+	res, err := controllerutil.CreateOrUpdate(ctx, service.client, cm, func() error {
+		cm.Labels = platformHelper.GenerateLabels(ac.Name)
+		cm.Data = data
+		return controllerutil.SetControllerReference(&ac, cm, service.Scheme)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to reconcile config map %s/%s", cm.Namespace, cm.Name)
+	}
+
+	log.V(1).Info("ConfigMap has been reconciled", "Namespace", cm.Namespace, "Name", cm.Name, "result", res)
+	return nil
+}
+
+// DeleteConfigMap deletes the ConfigMap of the generated settings, a missing ConfigMap is not an error.
+func (service K8SService) DeleteConfigMap(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	name := platformHelper.GetConfigMapName(ac)
+	err := service.CoreClient.ConfigMaps(ac.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete config map %s/%s", ac.Namespace, name)
+	}
+	log.Info("ConfigMap has been deleted", "Namespace", ac.Namespace, "Name", name)
+	return nil
+}
+
+// ReleaseSecret removes the admin console owner reference from the secret,
+// so it is not garbage collected together with the admin console.
+func (service K8SService) ReleaseSecret(ctx context.Context, ac adminConsoleApi.AdminConsole, name string) error {
+	secret, err := service.CoreClient.Secrets(ac.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get secret %s/%s", ac.Namespace, name)
+	}
+
+	secret.OwnerReferences = platformHelper.RemoveOwnerReference(secret.OwnerReferences, ac.UID)
+	if _, err := service.CoreClient.Secrets(ac.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to release secret %s/%s", ac.Namespace, name)
+	}
+	return nil
+}
+
+// GetSecretData returns the data of the secret.
+func (service K8SService) GetSecretData(ctx context.Context, namespace string, name string) (map[string][]byte, error) {
+	secret, err := service.CoreClient.Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get secret %s/%s", namespace, name)
+	}
+	return secret.Data, nil
+}
+
+// PatchSecretData sets the given keys of the secret, the other keys are kept.
+func (service K8SService) PatchSecretData(ctx context.Context, namespace string, name string, data map[string][]byte) error {
+	patch, err := json.Marshal(map[string]interface{}{"data": data})
+	if err != nil {
+		return err
+	}
+
+	_, err = service.CoreClient.Secrets(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to patch secret %s/%s", namespace, name)
+	}
+	return nil
+}
+
+// UpdateAdminConsole updates the CR. The status is not persisted by Update,
+// so the in-memory status is kept for the caller to write it later.
+func (s K8SService) UpdateAdminConsole(ctx context.Context, ac adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error) {
+	status := ac.Status
+	if err := s.client.Update(ctx, &ac); err != nil {
 		return nil, err
 	}
+	ac.Status = status
 	return &ac, nil
 }
 
@@ -251,39 +442,57 @@ func (service *K8SService) Init(config *rest.Config, scheme *runtime.Scheme, k8s
 	return nil
 }
 
-func (service K8SService) CreateKeycloakClient(kc *keycloakV1Api.KeycloakClient) error {
-	nsn := types.NamespacedName{
-		Namespace: kc.Namespace,
-		Name:      kc.Name,
+// CreateOrUpdateKeycloakClient reconciles the admin console KeycloakClient with the desired spec. The client is
+// attached to the KeycloakRealm from keycloakSpec.realmRef, if set.
+func (service K8SService) CreateOrUpdateKeycloakClient(ctx context.Context, ac adminConsoleApi.AdminConsole, desired *keycloakV1Api.KeycloakClient) error {
+	var realm *keycloakV1Api.KeycloakRealm
+	if name := ac.Spec.KeycloakSpec.RealmRef; name != "" {
+		realm = &keycloakV1Api.KeycloakRealm{}
+		if err := service.client.Get(ctx, types.NamespacedName{Namespace: ac.Namespace, Name: name}, realm); err != nil {
+			return errors.Wrapf(err, "failed to get KeycloakRealm %s/%s", ac.Namespace, name)
+		}
 	}
 
-	err := service.client.Get(context.TODO(), nsn, kc)
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			err := service.client.Create(context.TODO(), kc)
-			if err != nil {
-				return errors.Wrapf(err, "Failed to create Keycloak client %s/%s", kc.Namespace, kc.Name)
-			}
-			log.Info(fmt.Sprintf("Keycloak client %s/%s created", kc.Namespace, kc.Name))
-			// Successfully created
+	kc := &keycloakV1Api.KeycloakClient{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      desired.Name,
+			Namespace: desired.Namespace,
+		},
+	}
+
+	res, err := controllerutil.CreateOrUpdate(ctx, service.client, kc, func() error {
+		// only the fields derived from the admin console are managed, the target realm
+		// is filled in by the Keycloak operator when it is not set
+		kc.Spec.ClientId = desired.Spec.ClientId
+		kc.Spec.Public = desired.Spec.Public
+		kc.Spec.DirectAccess = desired.Spec.DirectAccess
+		kc.Spec.WebUrl = desired.Spec.WebUrl
+		kc.Spec.Secret = desired.Spec.Secret
+		kc.Spec.ServiceAccount = desired.Spec.ServiceAccount
+		kc.Spec.DefaultClientScopes = desired.Spec.DefaultClientScopes
+
+		if realm == nil {
 			return nil
 		}
-		// Some error occurred
-		return errors.Wrapf(err, "Failed to create Keycloak client %s/%s", kc.Namespace, kc.Name)
+		kc.Spec.TargetRealm = realm.Spec.RealmName
+		return controllerutil.SetOwnerReference(realm, kc, service.Scheme)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to reconcile Keycloak client %s/%s", desired.Namespace, desired.Name)
 	}
+	log.V(1).Info("Keycloak client has been reconciled", "Namespace", kc.Namespace, "Name", kc.Name, "result", res)
 
-	// Nothing to do
 	return nil
 }
 
-func (service K8SService) GetKeycloakClient(name string, namespace string) (keycloakV1Api.KeycloakClient, error) {
+func (service K8SService) GetKeycloakClient(ctx context.Context, name string, namespace string) (keycloakV1Api.KeycloakClient, error) {
 	out := keycloakV1Api.KeycloakClient{}
 	nsn := types.NamespacedName{
 		Namespace: namespace,
 		Name:      name,
 	}
 
-	err := service.client.Get(context.TODO(), nsn, &out)
+	err := service.client.Get(ctx, nsn, &out)
 	if err != nil {
 		return out, err
 	}
@@ -292,10 +501,35 @@ func (service K8SService) GetKeycloakClient(name string, namespace string) (keyc
 	return out, nil
 }
 
-func (s K8SService) CreateEDPComponentIfNotExist(ac adminConsoleApi.AdminConsole, url string, icon string) error {
-	if _, err := s.getEDPComponent(ac.Name, ac.Namespace); err != nil {
+// DeleteKeycloakClient requests deletion of the KeycloakClient CR and reports whether it is gone.
+// The Keycloak operator removes the client from the realm before it releases the CR finalizer,
+// so the caller should wait until true is returned.
+func (service K8SService) DeleteKeycloakClient(ctx context.Context, name string, namespace string) (bool, error) {
+	kc, err := service.GetKeycloakClient(ctx, name, namespace)
+	if err != nil {
+		// Without the KeycloakClient CRD there is no client to wait for.
+		if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "failed to get Keycloak client %s/%s", namespace, name)
+	}
+
+	if kc.DeletionTimestamp != nil {
+		log.Info("Waiting for Keycloak client to be removed from realm", "Namespace", namespace, "Name", name)
+		return false, nil
+	}
+
+	if err := service.client.Delete(ctx, &kc); err != nil && !k8serrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "failed to delete Keycloak client %s/%s", namespace, name)
+	}
+	log.Info("Keycloak client deletion has been requested", "Namespace", namespace, "Name", name)
+	return false, nil
+}
+
+func (s K8SService) CreateEDPComponentIfNotExist(ctx context.Context, ac adminConsoleApi.AdminConsole, url string, icon string) error {
+	if _, err := s.getEDPComponent(ctx, ac.Name, ac.Namespace); err != nil {
 		if k8serrors.IsNotFound(err) {
-			return s.createEDPComponent(ac, url, icon)
+			return s.createEDPComponent(ctx, ac, url, icon)
 		}
 		return errors.Wrapf(err, "failed to get edp component: %v", ac.Name)
 	}
@@ -303,9 +537,9 @@ func (s K8SService) CreateEDPComponentIfNotExist(ac adminConsoleApi.AdminConsole
 	return nil
 }
 
-func (s K8SService) getEDPComponent(name, namespace string) (*edpCompApi.EDPComponent, error) {
+func (s K8SService) getEDPComponent(ctx context.Context, name, namespace string) (*edpCompApi.EDPComponent, error) {
 	c := &edpCompApi.EDPComponent{}
-	err := s.client.Get(context.TODO(), types.NamespacedName{
+	err := s.client.Get(ctx, types.NamespacedName{
 		Namespace: namespace,
 		Name:      name,
 	}, c)
@@ -315,7 +549,7 @@ func (s K8SService) getEDPComponent(name, namespace string) (*edpCompApi.EDPComp
 	return c, nil
 }
 
-func (s K8SService) createEDPComponent(ac adminConsoleApi.AdminConsole, url string, icon string) error {
+func (s K8SService) createEDPComponent(ctx context.Context, ac adminConsoleApi.AdminConsole, url string, icon string) error {
 	obj := &edpCompApi.EDPComponent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ac.Name,
@@ -332,5 +566,49 @@ func (s K8SService) createEDPComponent(ac adminConsoleApi.AdminConsole, url stri
 		return err
 	}
 
-	return s.client.Create(context.TODO(), obj)
+	return s.client.Create(ctx, obj)
+}
+
+// DeleteEDPComponent deletes the EDPComponent published for the admin console.
+func (s K8SService) DeleteEDPComponent(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	c, err := s.getEDPComponent(ctx, ac.Name, ac.Namespace)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get edp component: %v", ac.Name)
+	}
+
+	if err := s.client.Delete(ctx, c); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete edp component: %v", ac.Name)
+	}
+	log.Info("edp component has been deleted", "name", ac.Name)
+	return nil
+}
+
+// ListEDPComponents returns the EDPComponents published in the namespace.
+func (s K8SService) ListEDPComponents(ctx context.Context, namespace string) ([]edpCompApi.EDPComponent, error) {
+	list := &edpCompApi.EDPComponentList{}
+	if err := s.client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list edp components in namespace %s", namespace)
+	}
+	return list.Items, nil
+}
+
+// ReleaseEDPComponent removes the admin console owner reference from the EDPComponent,
+// so it is not garbage collected together with the admin console.
+func (s K8SService) ReleaseEDPComponent(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	c, err := s.getEDPComponent(ctx, ac.Name, ac.Namespace)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get edp component: %v", ac.Name)
+	}
+
+	c.OwnerReferences = platformHelper.RemoveOwnerReference(c.OwnerReferences, ac.UID)
+	if err := s.client.Update(ctx, c); err != nil {
+		return errors.Wrapf(err, "failed to release edp component: %v", ac.Name)
+	}
+	return nil
 }
@@ -2,12 +2,11 @@ package openshift
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"os"
-	"strconv"
-	"strings"
+	"time"
 
+	appsV1Api "github.com/openshift/api/apps/v1"
+	routeV1Api "github.com/openshift/api/route/v1"
 	appsV1client "github.com/openshift/client-go/apps/clientset/versioned/typed/apps/v1"
 	authV1Client "github.com/openshift/client-go/authorization/clientset/versioned/typed/authorization/v1"
 	projectV1Client "github.com/openshift/client-go/project/clientset/versioned/typed/project/v1"
@@ -15,6 +14,7 @@ import (
 	securityV1Client "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
 	templateV1Client "github.com/openshift/client-go/template/clientset/versioned/typed/template/v1"
 	"github.com/pkg/errors"
+	autoscalingV2beta2Api "k8s.io/api/autoscaling/v2beta2"
 	coreV1Api "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,6 +23,7 @@ import (
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/helper"
@@ -47,125 +48,220 @@ type OpenshiftService struct {
 const (
 	deploymentTypeEnvName           = "DEPLOYMENT_TYPE"
 	deploymentConfigsDeploymentType = "deploymentConfigs"
+	platformType                    = "openshift"
 )
 
-func (service OpenshiftService) GenerateDbSettings(ac adminConsoleApi.AdminConsole) ([]coreV1Api.EnvVar, error) {
-	if !ac.Spec.DbSpec.Enabled {
-		msg := fmt.Sprintf("DB_ENABLED flag in %s spec is false.", ac.Name)
-		log.V(1).Info(msg)
+// UseDeploymentConfigs reports whether the admin console runs as a DeploymentConfig rather than a Deployment.
+func UseDeploymentConfigs() bool {
+	return os.Getenv(deploymentTypeEnvName) == deploymentConfigsDeploymentType
+}
 
-		return []coreV1Api.EnvVar{
-			{
-				Name:  "DB_ENABLED",
-				Value: "false",
-			},
-		}, nil
-	}
-
-	log.V(1).Info(fmt.Sprintf("Generating DB settings for Admin Console %s", ac.Name))
-	if platformHelper.ContainsEmptyString(ac.Spec.DbSpec.Name, ac.Spec.DbSpec.Hostname, ac.Spec.DbSpec.Port) {
-		return nil, errors.New("One or many DB settings field are empty!")
-	}
-
-	return []coreV1Api.EnvVar{
-		{
-			Name:  "PG_HOST",
-			Value: ac.Spec.DbSpec.Hostname,
-		},
-		{
-			Name:  "PG_PORT",
-			Value: ac.Spec.DbSpec.Port,
-		},
-		{
-			Name:  "PG_DATABASE",
-			Value: ac.Spec.DbSpec.Name,
-		},
-		{
-			Name:  "DB_ENABLED",
-			Value: strconv.FormatBool(ac.Spec.DbSpec.Enabled),
-		},
-	}, nil
+// PatchDeploymentEnv applies the env of the admin console container of the DeploymentConfig or Deployment,
+// depending on the deployment type, with server-side apply. The env is taken over from its previous field managers
+// before the first apply, like for a Deployment.
+func (service OpenshiftService) PatchDeploymentEnv(ctx context.Context, ac adminConsoleApi.AdminConsole, config platformHelper.WorkloadConfig) error {
+	if !UseDeploymentConfigs() {
+		return service.K8SService.PatchDeploymentEnv(ctx, ac, config)
+	}
+
+	dc, err := helper.GetDeploymentConfig(ctx, service.appClient, ac.Name, ac.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get deployment config %s/%s", ac.Namespace, ac.Name)
+	}
+
+	if _, err = platformHelper.SelectContainer(dc.Spec.Template.Spec.Containers, ac.Name); err != nil {
+		return err
+	}
+
+	migration, err := platformHelper.GenerateEnvMigrationPatch(dc.ObjectMeta, ac.Name, config)
+	if err != nil {
+		return err
+	}
+	if migration != nil {
+		_, err = service.appClient.DeploymentConfigs(ac.Namespace).Patch(ctx, ac.Name, types.JSONPatchType, migration, metav1.PatchOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to take env of deployment config %s/%s over from its field managers", ac.Namespace, ac.Name)
+		}
+		log.Info("Env of deployment config has been taken over from its field managers", "Namespace", ac.Namespace, "Name", ac.Name)
+	}
+
+	patch, err := platformHelper.GenerateEnvApplyPatch(appsV1Api.GroupVersion.WithKind("DeploymentConfig"), ac, config)
+	if err != nil {
+		return err
+	}
+
+	_, err = service.appClient.DeploymentConfigs(ac.Namespace).Patch(ctx, ac.Name, types.ApplyPatchType, patch,
+		metav1.PatchOptions{FieldManager: platformHelper.EnvFieldManager})
+	if k8serrors.IsConflict(err) {
+		return errors.Wrapf(err, "env of deployment config %s/%s is managed by another field manager", ac.Namespace, ac.Name)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to apply env of deployment config %s/%s", ac.Namespace, ac.Name)
+	}
+
+	return nil
 }
 
-func (service OpenshiftService) GenerateKeycloakSettings(ac adminConsoleApi.AdminConsole, keycloakUrl string) ([]coreV1Api.EnvVar, error) {
-	var out []coreV1Api.EnvVar
-
-	log.V(1).Info(fmt.Sprintf("Generating Keycloak settings for Admin Console %s", ac.Name))
-
-	out = []coreV1Api.EnvVar{
-		{
-			Name: "KEYCLOAK_CLIENT_ID",
-			ValueFrom: &coreV1Api.EnvVarSource{
-				SecretKeyRef: &coreV1Api.SecretKeySelector{
-					LocalObjectReference: coreV1Api.LocalObjectReference{
-						Name: "admin-console-client",
-					},
-					Key: "username",
-				},
-			},
-		},
-		{
-			Name: "KEYCLOAK_CLIENT_SECRET",
-			ValueFrom: &coreV1Api.EnvVarSource{
-				SecretKeyRef: &coreV1Api.SecretKeySelector{
-					LocalObjectReference: coreV1Api.LocalObjectReference{
-						Name: "admin-console-client",
-					},
-					Key: "password",
-				},
-			},
-		},
-		{
-			Name:  "KEYCLOAK_URL",
-			Value: keycloakUrl,
-		},
-		{
-			Name:  "AUTH_KEYCLOAK_ENABLED",
-			Value: strconv.FormatBool(ac.Spec.KeycloakSpec.Enabled),
-		},
-	}
-
-	return out, nil
+// RestartDeployment rolls the admin console pods out, so they pick up the changed secrets.
+func (service OpenshiftService) RestartDeployment(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	if !UseDeploymentConfigs() {
+		return service.K8SService.RestartDeployment(ctx, ac)
+	}
+
+	patch, err := platformHelper.GenerateRestartPatch(time.Now())
+	if err != nil {
+		return err
+	}
+
+	_, err = service.appClient.DeploymentConfigs(ac.Namespace).Patch(ctx, ac.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to restart deployment config %s/%s", ac.Namespace, ac.Name)
+	}
+	return nil
 }
 
-func (service OpenshiftService) PatchDeploymentEnv(ac adminConsoleApi.AdminConsole, env []coreV1Api.EnvVar) error {
-	if len(env) == 0 {
-		return nil
+// CreateOrUpdateDeployment reconciles the admin console DeploymentConfig or Deployment, depending on the deployment type.
+func (service OpenshiftService) CreateOrUpdateDeployment(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	if !UseDeploymentConfigs() {
+		return service.K8SService.CreateOrUpdateDeployment(ctx, ac)
 	}
 
-	if os.Getenv(deploymentTypeEnvName) == deploymentConfigsDeploymentType {
-		dc, err := helper.GetDeploymentConfig(service.appClient, ac.Name, ac.Namespace)
-		if err != nil {
-			if k8serrors.IsNotFound(err) {
-				log.Info(fmt.Sprintf("Deployment %s not found!", ac.Name))
-				return nil
-			}
-			return err
+	dc, err := helper.GetDeploymentConfig(ctx, service.appClient, ac.Name, ac.Namespace)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get deployment config %s/%s", ac.Namespace, ac.Name)
 		}
 
-		container, err := platformHelper.SelectContainer(dc.Spec.Template.Spec.Containers, ac.Name)
-		if err != nil {
-			return err
+		dc = &appsV1Api.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ac.Name,
+				Namespace: ac.Namespace,
+			},
+			Spec: appsV1Api.DeploymentConfigSpec{
+				Template: &coreV1Api.PodTemplateSpec{},
+			},
 		}
+	}
 
-		container.Env = platformHelper.UpdateEnv(container.Env, env)
+	dc.Labels = platformHelper.GenerateLabels(ac.Name)
+	var current *int32
+	if dc.ResourceVersion != "" {
+		current = &dc.Spec.Replicas
+	}
+	dc.Spec.Replicas = platformHelper.GetWorkloadReplicas(ac, current)
+	dc.Spec.Selector = platformHelper.GenerateLabels(ac.Name)
+	dc.Spec.Strategy = generateStrategy(ac, dc.Spec.Replicas, dc.Spec.Strategy)
+	dc.Spec.Triggers = appsV1Api.DeploymentTriggerPolicies{
+		{Type: appsV1Api.DeploymentTriggerOnConfigChange},
+	}
+	if dc.Spec.Template == nil {
+		dc.Spec.Template = &coreV1Api.PodTemplateSpec{}
+	}
+	platformHelper.MergePodTemplate(dc.Spec.Template,
+		platformHelper.GeneratePodTemplate(ac, platformHelper.GenerateBaseEnv(ac, platformType)), ac.Name,
+		platformHelper.GetKeptEnv(dc.ManagedFields, *dc.Spec.Template, ac.Name))
 
-		dc.Spec.Template.Spec.Containers = append(dc.Spec.Template.Spec.Containers, container)
+	if err := controllerutil.SetControllerReference(&ac, dc, service.Scheme); err != nil {
+		return err
+	}
 
-		jsonDc, err := json.Marshal(dc)
-		if err != nil {
-			return err
+	if dc.ResourceVersion == "" {
+		if _, err := service.appClient.DeploymentConfigs(ac.Namespace).Create(ctx, dc, metav1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to create deployment config %s/%s", ac.Namespace, ac.Name)
 		}
+		log.Info("Deployment config has been created", "Namespace", ac.Namespace, "Name", ac.Name)
+		return nil
+	}
 
-		_, err = service.appClient.DeploymentConfigs(dc.Namespace).Patch(context.TODO(), dc.Name, types.StrategicMergePatchType, jsonDc, metav1.PatchOptions{})
-		if err != nil {
-			return err
+	if _, err := service.appClient.DeploymentConfigs(ac.Namespace).Update(ctx, dc, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to update deployment config %s/%s", ac.Namespace, ac.Name)
+	}
+	return nil
+}
+
+// generateStrategy returns the rollout strategy of the DeploymentConfig. The parameters already set for the same
+// strategy type, e.g. the ones defaulted by the API server, are kept.
+func generateStrategy(ac adminConsoleApi.AdminConsole, replicas int32, current appsV1Api.DeploymentStrategy) appsV1Api.DeploymentStrategy {
+	strategy := appsV1Api.DeploymentStrategy{Type: appsV1Api.DeploymentStrategyTypeRecreate}
+	if platformHelper.UseRollingUpdate(ac, replicas) {
+		strategy.Type = appsV1Api.DeploymentStrategyTypeRolling
+	}
+	if current.Type != strategy.Type {
+		return strategy
+	}
+
+	strategy.RecreateParams = current.RecreateParams
+	strategy.RollingParams = current.RollingParams
+	return strategy
+}
+
+// CreateOrUpdateAvailability reconciles the HorizontalPodAutoscaler and the PodDisruptionBudget of the admin console
+// DeploymentConfig or Deployment, depending on the deployment type.
+func (service OpenshiftService) CreateOrUpdateAvailability(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	if !UseDeploymentConfigs() {
+		return service.K8SService.CreateOrUpdateAvailability(ctx, ac)
+	}
+
+	return service.K8SService.ReconcileAvailability(ctx, ac, autoscalingV2beta2Api.CrossVersionObjectReference{
+		APIVersion: appsV1Api.GroupVersion.String(),
+		Kind:       "DeploymentConfig",
+		Name:       ac.Name,
+	})
+}
+
+// CreateOrUpdateExposure reconciles the Route which exposes the admin console outside the cluster.
+func (service OpenshiftService) CreateOrUpdateExposure(ctx context.Context, ac adminConsoleApi.AdminConsole) error {
+	if platformHelper.GetExposure(ac, platformType) != adminConsoleApi.ExposureRoute {
+		return service.K8SService.CreateOrUpdateExposure(ctx, ac)
+	}
+
+	route, err := service.routeClient.Routes(ac.Namespace).Get(ctx, ac.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get route %s/%s", ac.Namespace, ac.Name)
+		}
+
+		route = &routeV1Api.Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ac.Name,
+				Namespace: ac.Namespace,
+			},
 		}
+	}
+
+	route.Labels = platformHelper.GenerateLabels(ac.Name)
+	route.Annotations = ac.Spec.Ingress.Annotations
+	route.Spec.Host = platformHelper.GetHost(ac)
+	route.Spec.Path = ""
+	if ac.Spec.BasePath != "" {
+		route.Spec.Path = platformHelper.GetPath(ac)
+	}
+	route.Spec.TLS = &routeV1Api.TLSConfig{
+		Termination:                   routeV1Api.TLSTerminationEdge,
+		InsecureEdgeTerminationPolicy: routeV1Api.InsecureEdgeTerminationPolicyRedirect,
+	}
+	route.Spec.To = routeV1Api.RouteTargetReference{
+		Kind: "Service",
+		Name: ac.Name,
+	}
+	route.Spec.WildcardPolicy = routeV1Api.WildcardPolicyNone
+
+	if err := controllerutil.SetControllerReference(&ac, route, service.Scheme); err != nil {
+		return err
+	}
 
+	if route.ResourceVersion == "" {
+		if _, err := service.routeClient.Routes(ac.Namespace).Create(ctx, route, metav1.CreateOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to create route %s/%s", ac.Namespace, ac.Name)
+		}
+		log.Info("Route has been created", "Namespace", ac.Namespace, "Name", ac.Name)
 		return nil
 	}
 
-	return service.K8SService.PatchDeploymentEnv(ac, env)
+	if _, err := service.routeClient.Routes(ac.Namespace).Update(ctx, route, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to update route %s/%s", ac.Namespace, ac.Name)
+	}
+	return nil
 }
 
 func (service *OpenshiftService) Init(config *rest.Config, scheme *runtime.Scheme, k8sClient *client.Client) error {
@@ -215,29 +311,50 @@ func (service *OpenshiftService) Init(config *rest.Config, scheme *runtime.Schem
 	return nil
 }
 
-// GetExternalUrl returns Route object from Openshift
-func (service OpenshiftService) GetExternalUrl(namespace string, name string) (*string, error) {
-	route, err := service.routeClient.Routes(namespace).Get(context.TODO(), name, metav1.GetOptions{})
-	if err != nil && k8serrors.IsNotFound(err) {
-		log.Info(fmt.Sprintf("Route %v in namespace %v not found", name, namespace))
-		return nil, err
-	} else if err != nil {
-		return nil, err
+// GetExternalUrl returns the admin console URL from the Route, or from the Ingress or HTTPRoute
+// when the admin console is exposed by one of them.
+func (service OpenshiftService) GetExternalUrl(ctx context.Context, ac adminConsoleApi.AdminConsole) (string, error) {
+	if platformHelper.GetExposure(ac, platformType) != adminConsoleApi.ExposureRoute {
+		return service.K8SService.GetExternalUrl(ctx, ac)
+	}
+
+	route, err := service.routeClient.Routes(ac.Namespace).Get(ctx, ac.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", &platformHelper.ExposureNotFoundError{Kind: "Route", Namespace: ac.Namespace, Name: ac.Name}
+		}
+		return "", errors.Wrapf(err, "failed to get route %s/%s", ac.Namespace, ac.Name)
+	}
+
+	return getRouteUrl(route)
+}
+
+// getRouteUrl returns the URL the Route is reachable at. The host generated by the router is used
+// when the Route has no host in its spec.
+func getRouteUrl(route *routeV1Api.Route) (string, error) {
+	host := route.Spec.Host
+	for _, ingress := range route.Status.Ingress {
+		if host != "" {
+			break
+		}
+		host = ingress.Host
+	}
+	if host == "" {
+		return "", &platformHelper.NoHostError{Kind: "Route", Namespace: route.Namespace, Name: route.Name}
 	}
 
-	var routeScheme = "http"
-	if route.Spec.TLS.Termination != "" {
-		routeScheme = "https"
+	scheme := "http"
+	if route.Spec.TLS != nil && route.Spec.TLS.Termination != "" {
+		scheme = "https"
 	}
 
-	u := fmt.Sprintf("%s://%s%s", routeScheme, route.Spec.Host, strings.TrimRight(route.Spec.Path, platformHelper.UrlCutset))
-	return &u, nil
+	return platformHelper.GenerateUrl(scheme, host, route.Spec.Path), nil
 }
 
-// IsDeploymentReady gets Deployment Config from Openshift, based on data from Admin Console
-func (service OpenshiftService) IsDeploymentReady(instance adminConsoleApi.AdminConsole) (bool, error) {
-	if os.Getenv(deploymentTypeEnvName) == deploymentConfigsDeploymentType {
-		return helper.IsDeploymentConfigReady(service.appClient, instance.Name, instance.Namespace)
+// GetDeploymentReadiness reports whether the admin console DeploymentConfig or Deployment has rolled out to all desired replicas.
+func (service OpenshiftService) GetDeploymentReadiness(ctx context.Context, instance adminConsoleApi.AdminConsole) (*helper.Readiness, error) {
+	if UseDeploymentConfigs() {
+		return helper.GetDeploymentConfigReadiness(ctx, service.appClient, &service.CoreClient, instance.Name, instance.Namespace)
 	}
-	return service.K8SService.IsDeploymentReady(instance)
+	return service.K8SService.GetDeploymentReadiness(ctx, instance)
 }
@@ -0,0 +1,57 @@
+package openshift
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	routeV1Api "github.com/openshift/api/route/v1"
+
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+func TestGetRouteUrl(t *testing.T) {
+	tests := []struct {
+		name  string
+		route routeV1Api.Route
+		want  string
+	}{
+		{
+			name:  "no tls",
+			route: routeV1Api.Route{Spec: routeV1Api.RouteSpec{Host: "console.example.com"}},
+			want:  "http://console.example.com",
+		},
+		{
+			name: "edge tls with a path",
+			route: routeV1Api.Route{Spec: routeV1Api.RouteSpec{
+				Host: "console.example.com",
+				Path: "/console/",
+				TLS:  &routeV1Api.TLSConfig{Termination: routeV1Api.TLSTerminationEdge},
+			}},
+			want: "https://console.example.com/console",
+		},
+		{
+			name: "host generated by the router",
+			route: routeV1Api.Route{Status: routeV1Api.RouteStatus{Ingress: []routeV1Api.RouteIngress{
+				{Host: "edp-admin-console-edp.apps.example.com"},
+			}}},
+			want: "http://edp-admin-console-edp.apps.example.com",
+		},
+		{
+			name: "no host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			url, err := getRouteUrl(&tt.route)
+			if tt.want == "" {
+				g.Expect(platformHelper.IsNoHost(err)).Should(BeTrue())
+				return
+			}
+			g.Expect(err).ShouldNot(HaveOccurred())
+			g.Expect(url).Should(Equal(tt.want))
+		})
+	}
+}
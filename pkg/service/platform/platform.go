@@ -1,32 +1,49 @@
 package platform
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
 	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
 	"github.com/pkg/errors"
-	coreV1Api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/helper"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/kubernetes"
 	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/openshift"
 )
 
 type PlatformService interface {
-	CreateSecret(ac adminConsoleApi.AdminConsole, name string, data map[string][]byte) error
-	GenerateDbSettings(ac adminConsoleApi.AdminConsole) ([]coreV1Api.EnvVar, error)
-	GenerateKeycloakSettings(ac adminConsoleApi.AdminConsole, keycloakUrl string) ([]coreV1Api.EnvVar, error)
-	PatchDeploymentEnv(ac adminConsoleApi.AdminConsole, env []coreV1Api.EnvVar) error
-	UpdateAdminConsole(ac adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error)
-	GetKeycloakClient(name string, namespace string) (keycloakV1Api.KeycloakClient, error)
-	CreateKeycloakClient(kc *keycloakV1Api.KeycloakClient) error
-	GetExternalUrl(namespace string, name string) (*string, error)
-	IsDeploymentReady(instance adminConsoleApi.AdminConsole) (bool, error)
-	CreateEDPComponentIfNotExist(instance adminConsoleApi.AdminConsole, url string, icon string) error
+	CreateSecret(ctx context.Context, ac adminConsoleApi.AdminConsole, name string, data map[string][]byte) error
+	PatchDeploymentEnv(ctx context.Context, ac adminConsoleApi.AdminConsole, config platformHelper.WorkloadConfig) error
+	CreateOrUpdateConfigMap(ctx context.Context, ac adminConsoleApi.AdminConsole, data map[string]string) error
+	DeleteConfigMap(ctx context.Context, ac adminConsoleApi.AdminConsole) error
+	RestartDeployment(ctx context.Context, ac adminConsoleApi.AdminConsole) error
+	UpdateAdminConsole(ctx context.Context, ac adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error)
+	GetKeycloakClient(ctx context.Context, name string, namespace string) (keycloakV1Api.KeycloakClient, error)
+	CreateOrUpdateKeycloakClient(ctx context.Context, ac adminConsoleApi.AdminConsole, kc *keycloakV1Api.KeycloakClient) error
+	GetExternalUrl(ctx context.Context, ac adminConsoleApi.AdminConsole) (string, error)
+	GetDeploymentReadiness(ctx context.Context, instance adminConsoleApi.AdminConsole) (*helper.Readiness, error)
+	CreateEDPComponentIfNotExist(ctx context.Context, instance adminConsoleApi.AdminConsole, url string, icon string) error
+	CreateOrUpdateDeployment(ctx context.Context, ac adminConsoleApi.AdminConsole) error
+	CreateOrUpdateService(ctx context.Context, ac adminConsoleApi.AdminConsole) error
+	CreateOrUpdateAvailability(ctx context.Context, ac adminConsoleApi.AdminConsole) error
+	CreateOrUpdateExposure(ctx context.Context, ac adminConsoleApi.AdminConsole) error
+	DeleteSecret(ctx context.Context, ac adminConsoleApi.AdminConsole, name string) error
+	GetSecretData(ctx context.Context, namespace string, name string) (map[string][]byte, error)
+	PatchSecretData(ctx context.Context, namespace string, name string, data map[string][]byte) error
+	ReleaseSecret(ctx context.Context, ac adminConsoleApi.AdminConsole, name string) error
+	DeleteKeycloakClient(ctx context.Context, name string, namespace string) (bool, error)
+	DeleteEDPComponent(ctx context.Context, ac adminConsoleApi.AdminConsole) error
+	ListEDPComponents(ctx context.Context, namespace string) ([]edpCompApi.EDPComponent, error)
+	ReleaseEDPComponent(ctx context.Context, ac adminConsoleApi.AdminConsole) error
 }
 
 const (
@@ -34,7 +51,8 @@ const (
 	Kubernetes string = "kubernetes"
 )
 
-func NewPlatformService(platformType string, scheme *runtime.Scheme, k8sClient *client.Client) (PlatformService, error) {
+// NewPlatformService returns the platform service of the platform type. Every call to it is given at most callTimeout.
+func NewPlatformService(platformType string, scheme *runtime.Scheme, k8sClient *client.Client, callTimeout time.Duration) (PlatformService, error) {
 	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		clientcmd.NewDefaultClientConfigLoadingRules(),
 		&clientcmd.ConfigOverrides{},
@@ -52,7 +70,7 @@ func NewPlatformService(platformType string, scheme *runtime.Scheme, k8sClient *
 			return nil, errors.Wrap(err, "Failed to initialize Kubernetes platform service!")
 		}
 
-		return platformService, nil
+		return instrumentedService{service: platformService, callTimeout: callTimeout}, nil
 	case Openshift:
 		platformService := openshift.OpenshiftService{}
 		err = platformService.Init(restConfig, scheme, k8sClient)
@@ -60,7 +78,7 @@ func NewPlatformService(platformType string, scheme *runtime.Scheme, k8sClient *
 			return nil, errors.Wrap(err, "Failed to initialize OpenShift platform service!")
 		}
 
-		return platformService, nil
+		return instrumentedService{service: platformService, callTimeout: callTimeout}, nil
 	default:
 		err := errors.New(fmt.Sprintf("Platform %s is not supported!", platformType))
 		return nil, err
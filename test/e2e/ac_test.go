@@ -17,7 +17,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 
 	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
-	"github.com/epam/edp-admin-console-operator/v2/pkg/controller/helper"
 	testHelper "github.com/epam/edp-admin-console-operator/v2/test/helper"
 )
 
@@ -55,7 +54,7 @@ func setupPrerequisite() error {
 		return err
 	}
 
-	namespace, err = helper.GetWatchNamespace()
+	namespace, err = testHelper.GetTestNamespace()
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,8 @@
 package helper
 
 import (
+	"errors"
+
 	"github.com/epam/edp-admin-console-operator/v2/pkg/controller/helper"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,23 +16,37 @@ func GetBoolPointer(val bool) *bool {
 	return &val
 }
 
+// GetTestNamespace returns the namespace the tests create objects in, the first of the watched namespaces.
+func GetTestNamespace() (string, error) {
+	namespaces, err := helper.GetWatchNamespaces()
+	if err != nil {
+		return "", err
+	}
+	if len(namespaces) == 0 {
+		return "", errors.New("tests require a watch namespace")
+	}
+	return namespaces[0], nil
+}
+
 func InitClient(initScheme func(scheme *runtime.Scheme)) (client.Client, error) {
 	scheme := runtime.NewScheme()
 	initScheme(scheme)
 
-	ns, err := helper.GetWatchNamespace()
+	namespaces, err := helper.GetWatchNamespaces()
 	if err != nil {
 		return nil, err
 	}
 
 	cfg := ctrl.GetConfigOrDie()
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+	options := ctrl.Options{
 		Scheme: scheme,
 		MapperProvider: func(c *rest.Config) (meta.RESTMapper, error) {
 			return apiutil.NewDynamicRESTMapper(cfg)
 		},
-		Namespace: ns,
-	})
+	}
+	helper.SetWatchNamespaces(&options, namespaces)
+
+	mgr, err := ctrl.NewManager(cfg, options)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,326 @@
+package helper
+
+import (
+	"context"
+	"sync"
+
+	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
+	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
+	coreV1Api "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/helper"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+)
+
+// FakePlatformService is an in-memory PlatformService. It keeps the secrets, the KeycloakClients, the EDPComponents
+// and the deployment env the admin console service creates, and fails the methods listed in Errors.
+type FakePlatformService struct {
+	mu sync.Mutex
+
+	// Errors are returned by the methods with the same name, e.g. "CreateSecret".
+	Errors map[string]error
+	// Readiness is returned by GetDeploymentReadiness.
+	Readiness helper.Readiness
+	// Url is returned by GetExternalUrl.
+	Url string
+	// KeycloakClientOwners are set on every stored KeycloakClient, like the Keycloak operator links a client to its realm.
+	KeycloakClientOwners []metav1.OwnerReference
+	// KeycloakClientDeleted is returned by DeleteKeycloakClient.
+	KeycloakClientDeleted bool
+
+	Secrets         map[types.NamespacedName]map[string][]byte
+	KeycloakClients map[types.NamespacedName]keycloakV1Api.KeycloakClient
+	// EDPComponents are the URLs of the published EDPComponent and of the ones listed by ListEDPComponents.
+	EDPComponents map[types.NamespacedName]string
+	Env           map[types.NamespacedName][]coreV1Api.EnvVar
+	// Configs are the last applied configurations of the admin console containers, Env holds their env.
+	Configs    map[types.NamespacedName]platformHelper.WorkloadConfig
+	ConfigMaps map[types.NamespacedName]map[string]string
+	// Calls are the names of the called methods in order.
+	Calls []string
+}
+
+// NewFakePlatformService returns a service whose deployment is ready and whose admin console is exposed on url.
+func NewFakePlatformService(url string) *FakePlatformService {
+	return &FakePlatformService{
+		Errors:                map[string]error{},
+		Readiness:             helper.Readiness{Ready: true},
+		Url:                   url,
+		KeycloakClientDeleted: true,
+		Secrets:               map[types.NamespacedName]map[string][]byte{},
+		KeycloakClients:       map[types.NamespacedName]keycloakV1Api.KeycloakClient{},
+		EDPComponents:         map[types.NamespacedName]string{},
+		Env:                   map[types.NamespacedName][]coreV1Api.EnvVar{},
+		Configs:               map[types.NamespacedName]platformHelper.WorkloadConfig{},
+		ConfigMaps:            map[types.NamespacedName]map[string]string{},
+	}
+}
+
+// SetError makes the method fail with err, or succeed again when err is nil.
+func (s *FakePlatformService) SetError(method string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		delete(s.Errors, method)
+		return
+	}
+	s.Errors[method] = err
+}
+
+// Called reports whether the method has been called.
+func (s *FakePlatformService) Called(method string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.Calls {
+		if c == method {
+			return true
+		}
+	}
+	return false
+}
+
+// call records the call of the method and returns its injected error.
+func (s *FakePlatformService) call(method string) error {
+	s.Calls = append(s.Calls, method)
+	return s.Errors[method]
+}
+
+func key(namespace, name string) types.NamespacedName {
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+func (s *FakePlatformService) CreateSecret(_ context.Context, ac adminConsoleApi.AdminConsole, name string, data map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("CreateSecret"); err != nil {
+		return err
+	}
+	if _, ok := s.Secrets[key(ac.Namespace, name)]; !ok {
+		s.Secrets[key(ac.Namespace, name)] = data
+	}
+	return nil
+}
+
+func (s *FakePlatformService) PatchDeploymentEnv(_ context.Context, ac adminConsoleApi.AdminConsole, config platformHelper.WorkloadConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("PatchDeploymentEnv"); err != nil {
+		return err
+	}
+	s.Env[key(ac.Namespace, ac.Name)] = config.Env
+	s.Configs[key(ac.Namespace, ac.Name)] = config
+	return nil
+}
+
+func (s *FakePlatformService) CreateOrUpdateConfigMap(_ context.Context, ac adminConsoleApi.AdminConsole, data map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("CreateOrUpdateConfigMap"); err != nil {
+		return err
+	}
+	s.ConfigMaps[key(ac.Namespace, platformHelper.GetConfigMapName(ac))] = data
+	return nil
+}
+
+func (s *FakePlatformService) DeleteConfigMap(_ context.Context, ac adminConsoleApi.AdminConsole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("DeleteConfigMap"); err != nil {
+		return err
+	}
+	delete(s.ConfigMaps, key(ac.Namespace, platformHelper.GetConfigMapName(ac)))
+	return nil
+}
+
+func (s *FakePlatformService) RestartDeployment(_ context.Context, _ adminConsoleApi.AdminConsole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.call("RestartDeployment")
+}
+
+func (s *FakePlatformService) UpdateAdminConsole(_ context.Context, ac adminConsoleApi.AdminConsole) (*adminConsoleApi.AdminConsole, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("UpdateAdminConsole"); err != nil {
+		return nil, err
+	}
+	return &ac, nil
+}
+
+func (s *FakePlatformService) GetKeycloakClient(_ context.Context, name string, namespace string) (keycloakV1Api.KeycloakClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("GetKeycloakClient"); err != nil {
+		return keycloakV1Api.KeycloakClient{}, err
+	}
+	kc, ok := s.KeycloakClients[key(namespace, name)]
+	if !ok {
+		return keycloakV1Api.KeycloakClient{}, k8sErrors.NewNotFound(schema.GroupResource{Group: "v1.edp.epam.com", Resource: "keycloakclients"}, name)
+	}
+	return kc, nil
+}
+
+func (s *FakePlatformService) CreateOrUpdateKeycloakClient(_ context.Context, _ adminConsoleApi.AdminConsole, kc *keycloakV1Api.KeycloakClient) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("CreateOrUpdateKeycloakClient"); err != nil {
+		return err
+	}
+	stored := *kc.DeepCopy()
+	stored.OwnerReferences = s.KeycloakClientOwners
+	s.KeycloakClients[key(kc.Namespace, kc.Name)] = stored
+	return nil
+}
+
+func (s *FakePlatformService) GetExternalUrl(_ context.Context, _ adminConsoleApi.AdminConsole) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("GetExternalUrl"); err != nil {
+		return "", err
+	}
+	return s.Url, nil
+}
+
+func (s *FakePlatformService) GetDeploymentReadiness(_ context.Context, _ adminConsoleApi.AdminConsole) (*helper.Readiness, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("GetDeploymentReadiness"); err != nil {
+		return nil, err
+	}
+	r := s.Readiness
+	return &r, nil
+}
+
+func (s *FakePlatformService) CreateEDPComponentIfNotExist(_ context.Context, instance adminConsoleApi.AdminConsole, url string, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("CreateEDPComponentIfNotExist"); err != nil {
+		return err
+	}
+	if _, ok := s.EDPComponents[key(instance.Namespace, instance.Name)]; !ok {
+		s.EDPComponents[key(instance.Namespace, instance.Name)] = url
+	}
+	return nil
+}
+
+func (s *FakePlatformService) CreateOrUpdateDeployment(_ context.Context, _ adminConsoleApi.AdminConsole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.call("CreateOrUpdateDeployment")
+}
+
+func (s *FakePlatformService) CreateOrUpdateService(_ context.Context, _ adminConsoleApi.AdminConsole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.call("CreateOrUpdateService")
+}
+
+func (s *FakePlatformService) CreateOrUpdateAvailability(_ context.Context, _ adminConsoleApi.AdminConsole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.call("CreateOrUpdateAvailability")
+}
+
+func (s *FakePlatformService) CreateOrUpdateExposure(_ context.Context, _ adminConsoleApi.AdminConsole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.call("CreateOrUpdateExposure")
+}
+
+func (s *FakePlatformService) DeleteSecret(_ context.Context, ac adminConsoleApi.AdminConsole, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("DeleteSecret"); err != nil {
+		return err
+	}
+	delete(s.Secrets, key(ac.Namespace, name))
+	return nil
+}
+
+func (s *FakePlatformService) GetSecretData(_ context.Context, namespace string, name string) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("GetSecretData"); err != nil {
+		return nil, err
+	}
+	data, ok := s.Secrets[key(namespace, name)]
+	if !ok {
+		return nil, k8sErrors.NewNotFound(coreV1Api.Resource("secrets"), name)
+	}
+	return data, nil
+}
+
+func (s *FakePlatformService) PatchSecretData(_ context.Context, namespace string, name string, data map[string][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("PatchSecretData"); err != nil {
+		return err
+	}
+	secret, ok := s.Secrets[key(namespace, name)]
+	if !ok {
+		return k8sErrors.NewNotFound(coreV1Api.Resource("secrets"), name)
+	}
+	for k, v := range data {
+		secret[k] = v
+	}
+	return nil
+}
+
+func (s *FakePlatformService) ReleaseSecret(_ context.Context, _ adminConsoleApi.AdminConsole, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.call("ReleaseSecret")
+}
+
+func (s *FakePlatformService) DeleteKeycloakClient(_ context.Context, name string, namespace string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("DeleteKeycloakClient"); err != nil {
+		return false, err
+	}
+	if !s.KeycloakClientDeleted {
+		return false, nil
+	}
+	delete(s.KeycloakClients, key(namespace, name))
+	return true, nil
+}
+
+func (s *FakePlatformService) DeleteEDPComponent(_ context.Context, ac adminConsoleApi.AdminConsole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("DeleteEDPComponent"); err != nil {
+		return err
+	}
+	delete(s.EDPComponents, key(ac.Namespace, ac.Name))
+	return nil
+}
+
+func (s *FakePlatformService) ListEDPComponents(_ context.Context, namespace string) ([]edpCompApi.EDPComponent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call("ListEDPComponents"); err != nil {
+		return nil, err
+	}
+	var components []edpCompApi.EDPComponent
+	for k, url := range s.EDPComponents {
+		if k.Namespace != namespace {
+			continue
+		}
+		components = append(components, edpCompApi.EDPComponent{
+			ObjectMeta: metav1.ObjectMeta{Name: k.Name, Namespace: k.Namespace},
+			Spec:       edpCompApi.EDPComponentSpec{Type: k.Name, Url: url, Visible: true},
+		})
+	}
+	return components, nil
+}
+
+func (s *FakePlatformService) ReleaseEDPComponent(_ context.Context, _ adminConsoleApi.AdminConsole) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.call("ReleaseEDPComponent")
+}
@@ -0,0 +1,551 @@
+package integration
+
+import (
+	"context"
+	"time"
+
+	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/controller/adminconsole"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/helper"
+	adminConsoleSpec "github.com/epam/edp-admin-console-operator/v2/pkg/service/admin_console/spec"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+	testHelper "github.com/epam/edp-admin-console-operator/v2/test/helper"
+)
+
+const (
+	acName      = "edp-admin-console"
+	externalUrl = "https://admin-console.example.com"
+)
+
+var _ = Describe("AdminConsole reconciliation", func() {
+	var (
+		ctx       context.Context
+		namespace string
+		platform  *testHelper.FakePlatformService
+		recorder  *record.FakeRecorder
+		r         *adminconsole.ReconcileAdminConsole
+		request   reconcile.Request
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "admin-console-"}}
+		Expect(client.Create(ctx, ns)).Should(Succeed())
+		namespace = ns.Name
+
+		platform = testHelper.NewFakePlatformService(externalUrl)
+		platform.KeycloakClientOwners = createKeycloak(ctx, namespace)
+
+		recorder = record.NewFakeRecorder(100)
+
+		var err error
+		r, err = adminconsole.NewReconcileAdminConsoleWithPlatform(client, scheme, ctrl.Log, recorder, platform)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		request = reconcile.Request{NamespacedName: k8sClient.ObjectKey{Namespace: namespace, Name: acName}}
+	})
+
+	// createAdminConsole creates the AdminConsole under test, with the Keycloak integration enabled.
+	createAdminConsole := func(mutate ...func(ac *adminConsoleApi.AdminConsole)) {
+		ac := &adminConsoleApi.AdminConsole{
+			ObjectMeta: metav1.ObjectMeta{Name: acName, Namespace: namespace},
+			Spec: adminConsoleApi.AdminConsoleSpec{
+				KeycloakSpec: adminConsoleApi.KeycloakSpec{Enabled: true},
+				EdpSpec:      adminConsoleApi.EdpSpec{Name: "edp", DnsWildcard: "example.com", TestReportTools: "Allure"},
+				DbSpec:       adminConsoleApi.AdminConsoleDbSettings{Enabled: true, Hostname: "edp-db", Port: "5432", Name: "edp-db"},
+			},
+		}
+		for _, m := range mutate {
+			m(ac)
+		}
+		Expect(client.Create(ctx, ac)).Should(Succeed())
+	}
+
+	getAdminConsole := func() *adminConsoleApi.AdminConsole {
+		ac := &adminConsoleApi.AdminConsole{}
+		Expect(client.Get(ctx, request.NamespacedName, ac)).Should(Succeed())
+		return ac
+	}
+
+	expectCondition := func(conditionType string, status metav1.ConditionStatus, reason string) {
+		c := meta.FindStatusCondition(getAdminConsole().Status.Conditions, conditionType)
+		Expect(c).ShouldNot(BeNil(), "condition %s is not set", conditionType)
+		Expect(c.Status).Should(Equal(status), "condition %s: %s", conditionType, c.Message)
+		Expect(c.Reason).Should(Equal(reason), "condition %s: %s", conditionType, c.Message)
+	}
+
+	// events drains the events recorded so far.
+	events := func() []string {
+		var recorded []string
+		for {
+			select {
+			case e := <-recorder.Events:
+				recorded = append(recorded, e)
+			default:
+				return recorded
+			}
+		}
+	}
+
+	Context("when every step succeeds", func() {
+		It("reports the AdminConsole ready", func() {
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			ac := getAdminConsole()
+			Expect(ac.Status.Available).Should(BeTrue())
+			Expect(ac.Finalizers).Should(ContainElement(adminconsole.FinalizerName))
+			for _, t := range []string{
+				adminConsoleApi.ConditionDeploymentReady,
+				adminConsoleApi.ConditionSecretsReady,
+				adminConsoleApi.ConditionKeycloakClientReady,
+				adminConsoleApi.ConditionEDPComponentPublished,
+				adminConsoleApi.ConditionDatabaseProvisioned,
+				adminConsoleApi.ConditionEnvPatched,
+			} {
+				Expect(meta.IsStatusConditionTrue(ac.Status.Conditions, t)).Should(BeTrue(), "condition %s", t)
+			}
+			expectCondition(adminConsoleApi.ConditionDatabaseReady, metav1.ConditionUnknown, adminConsoleApi.ReasonDbCredentialsNotSet)
+			expectCondition(adminConsoleApi.ConditionReady, metav1.ConditionTrue, adminConsoleApi.ReasonReconcileSucceeded)
+			Expect(meta.FindStatusCondition(ac.Status.Conditions, adminConsoleApi.ConditionReady).Message).
+				Should(ContainSubstring(adminConsoleApi.ConditionDatabaseReady))
+
+			Expect(platform.Secrets).Should(HaveKey(k8sClient.ObjectKey{Namespace: namespace, Name: adminConsoleSpec.ReaderSecretName}))
+			Expect(platform.KeycloakClients).Should(HaveKey(request.NamespacedName))
+			Expect(platform.EDPComponents).Should(HaveKeyWithValue(request.NamespacedName, externalUrl))
+			Expect(platform.Env[request.NamespacedName]).Should(ContainElements(
+				corev1.EnvVar{Name: "KEYCLOAK_URL", Value: "https://keycloak.example.com/auth/realms/edp"},
+				corev1.EnvVar{Name: "PG_DATABASE", Value: "edp-db"},
+				corev1.EnvVar{Name: "HOST", Value: externalUrl},
+				corev1.EnvVar{Name: "EDP_NAME", Value: "edp"},
+				corev1.EnvVar{Name: "TEST_REPORT_TOOLS", Value: "Allure"},
+			))
+			Expect(events()).Should(ContainElement(HavePrefix(corev1.EventTypeNormal + " " + adminConsoleApi.ReasonEDPComponentPublished)))
+		})
+	})
+
+	Context("when the deployment is rolling out", func() {
+		It("waits for the deployment without an error", func() {
+			platform.Readiness = helper.Readiness{Message: "Waiting for the rollout to finish, 0 of 1 replicas updated"}
+			createAdminConsole()
+
+			result, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.RequeueAfter).Should(Equal(adminconsole.DefaultRequeueTime * time.Second))
+
+			expectCondition(adminConsoleApi.ConditionDeploymentReady, metav1.ConditionFalse, adminConsoleApi.ReasonDeploymentNotReady)
+			expectCondition(adminConsoleApi.ConditionReady, metav1.ConditionFalse, adminConsoleApi.ReasonDeploymentNotReady)
+			Expect(platform.Called("CreateSecret")).Should(BeFalse())
+		})
+
+		It("reports a rollout which has exceeded its progress deadline", func() {
+			platform.Readiness = helper.Readiness{Stuck: true, Message: "Rollout has exceeded its progress deadline"}
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionDeploymentReady, metav1.ConditionFalse, adminConsoleApi.ReasonProgressDeadlineExceeded)
+			Expect(events()).Should(ContainElement(HavePrefix(corev1.EventTypeWarning + " " + adminConsoleApi.ReasonProgressDeadlineExceeded)))
+		})
+	})
+
+	Context("when the deployment cannot be installed", func() {
+		It("reports the failed install", func() {
+			platform.SetError("CreateOrUpdateDeployment", errors.New("deployments.apps is forbidden"))
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionDeploymentReady, metav1.ConditionFalse, adminConsoleApi.ReasonDeploymentReconcileFailed)
+		})
+	})
+
+	Context("when exposing the configuration fails", func() {
+		It("reports secrets which cannot be created", func() {
+			platform.SetError("CreateSecret", errors.New("secrets is forbidden"))
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionSecretsReady, metav1.ConditionFalse, adminConsoleApi.ReasonSecretsCreationFailed)
+			expectCondition(adminConsoleApi.ConditionReady, metav1.ConditionFalse, adminConsoleApi.ReasonSecretsCreationFailed)
+			Expect(events()).Should(ContainElement(HavePrefix(corev1.EventTypeWarning + " " + adminConsoleApi.ReasonSecretsCreationFailed)))
+		})
+
+		It("reports an Ingress which does not exist yet", func() {
+			platform.SetError("GetExternalUrl", &platformHelper.ExposureNotFoundError{Kind: "Ingress", Namespace: namespace, Name: acName})
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionSecretsReady, metav1.ConditionTrue, adminConsoleApi.ReasonSecretsCreated)
+			expectCondition(adminConsoleApi.ConditionKeycloakClientReady, metav1.ConditionFalse, adminConsoleApi.ReasonExposureNotFound)
+		})
+
+		It("reports a KeycloakClient which cannot be created", func() {
+			platform.SetError("CreateOrUpdateKeycloakClient", errors.New("keycloakclients is forbidden"))
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionKeycloakClientReady, metav1.ConditionFalse, adminConsoleApi.ReasonKeycloakClientCreationFailed)
+		})
+
+		It("reports an EDPComponent which cannot be published", func() {
+			platform.SetError("CreateEDPComponentIfNotExist", errors.New("edpcomponents is forbidden"))
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionKeycloakClientReady, metav1.ConditionTrue, adminConsoleApi.ReasonKeycloakClientCreated)
+			expectCondition(adminConsoleApi.ConditionEDPComponentPublished, metav1.ConditionFalse, adminConsoleApi.ReasonEDPComponentPublishFailed)
+		})
+	})
+
+	Context("when the integration fails", func() {
+		It("reports a KeycloakClient without a realm", func() {
+			platform.KeycloakClientOwners = nil
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionKeycloakClientReady, metav1.ConditionFalse, adminConsoleApi.ReasonKeycloakNotFound)
+		})
+
+		It("reports a KeycloakClient which does not exist", func() {
+			platform.SetError("GetKeycloakClient", k8sErrors.NewNotFound(keycloakV1Api.SchemeGroupVersion.WithResource("keycloakclients").GroupResource(), acName))
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionKeycloakClientReady, metav1.ConditionFalse, adminConsoleApi.ReasonKeycloakNotFound)
+		})
+
+		It("reports an environment which cannot be patched", func() {
+			platform.SetError("PatchDeploymentEnv", errors.New("deployments.apps is forbidden"))
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionEnvPatched, metav1.ConditionFalse, adminConsoleApi.ReasonEnvPatchFailed)
+		})
+
+		It("reports env owned by another field manager", func() {
+			platform.SetError("PatchDeploymentEnv", k8sErrors.NewConflict(appsv1.Resource("deployments"), acName,
+				errors.New(`Apply failed with 1 conflict: conflict with "kubectl-edit": .spec.template.spec.containers[name="edp-admin-console"].env[name="KEYCLOAK_URL"].value`)))
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionEnvPatched, metav1.ConditionFalse, adminConsoleApi.ReasonEnvConflict)
+		})
+
+		It("removes the Keycloak settings once the Keycloak integration is disabled", func() {
+			createAdminConsole(func(ac *adminConsoleApi.AdminConsole) {
+				ac.Spec.Features = &adminConsoleApi.FeaturesSpec{BuildTools: []string{"maven", "npm"}}
+			})
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(platform.Env[request.NamespacedName]).ShouldNot(BeEmpty())
+
+			ac := getAdminConsole()
+			ac.Spec.KeycloakSpec.Enabled = false
+			Expect(client.Update(ctx, ac)).Should(Succeed())
+
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			env := platform.Env[request.NamespacedName]
+			Expect(env).Should(ContainElements(
+				corev1.EnvVar{Name: "BUILD_TOOLS", Value: "maven,npm"},
+				corev1.EnvVar{Name: "PG_HOST", Value: "edp-db"},
+			))
+			Expect(env).ShouldNot(ContainElement(WithTransform(envName, HavePrefix("KEYCLOAK_"))))
+			expectCondition(adminConsoleApi.ConditionEnvPatched, metav1.ConditionTrue, adminConsoleApi.ReasonEnvPatched)
+		})
+
+		It("applies the feature lists with the Keycloak and DB settings", func() {
+			createAdminConsole(func(ac *adminConsoleApi.AdminConsole) {
+				ac.Spec.Features = &adminConsoleApi.FeaturesSpec{
+					IntegrationStrategies: []string{"Create", "Clone", "Import"},
+					CiTools:               []string{"Jenkins", "GitLab CI"},
+				}
+			})
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(platform.Env[request.NamespacedName]).Should(ContainElements(
+				corev1.EnvVar{Name: "INTEGRATION_STRATEGIES", Value: "Create,Clone,Import"},
+				corev1.EnvVar{Name: "CI_TOOLS", Value: "Jenkins,GitLab CI"},
+				corev1.EnvVar{Name: "PG_HOST", Value: "edp-db"},
+			))
+		})
+
+		It("parks the AdminConsole with an unknown feature", func() {
+			createAdminConsole(func(ac *adminConsoleApi.AdminConsole) {
+				ac.Spec.Features = &adminConsoleApi.FeaturesSpec{BuildTools: []string{"ant"}}
+			})
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionEnvPatched, metav1.ConditionFalse, adminConsoleApi.ReasonFeaturesInvalid)
+			expectCondition(adminConsoleApi.ConditionStalled, metav1.ConditionTrue, adminConsoleApi.ReasonPermanentError)
+			Expect(platform.Called("PatchDeploymentEnv")).Should(BeFalse())
+		})
+
+		It("reports a database which is not reachable", func() {
+			platform.Secrets[k8sClient.ObjectKey{Namespace: namespace, Name: "db-credentials"}] = map[string][]byte{
+				"username": []byte("admin"),
+				"password": []byte("password"),
+			}
+			createAdminConsole(func(ac *adminConsoleApi.AdminConsole) {
+				ac.Spec.DbSpec.Hostname = "127.0.0.1"
+				ac.Spec.DbSpec.Port = "1"
+				ac.Spec.DbSpec.Name = "edp-db"
+				ac.Spec.DbSpec.CredentialsSecretRef = &adminConsoleApi.DbCredentialsSecretRef{Name: "db-credentials"}
+			})
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionDatabaseReady, metav1.ConditionFalse, adminConsoleApi.ReasonDatabaseConnectionFailed)
+			Expect(platform.Called("PatchDeploymentEnv")).Should(BeFalse())
+		})
+
+		It("parks the AdminConsole on a permanent error until the spec changes", func() {
+			createAdminConsole(func(ac *adminConsoleApi.AdminConsole) {
+				ac.Spec.DbSpec.Name = ""
+			})
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionEnvPatched, metav1.ConditionFalse, adminConsoleApi.ReasonDbSettingsInvalid)
+			expectCondition(adminConsoleApi.ConditionStalled, metav1.ConditionTrue, adminConsoleApi.ReasonPermanentError)
+			expectCondition(adminConsoleApi.ConditionReady, metav1.ConditionFalse, adminConsoleApi.ReasonPermanentError)
+
+			platform.Calls = nil
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(platform.Calls).Should(BeEmpty())
+
+			ac := getAdminConsole()
+			ac.Spec.DbSpec.Name = "edp-db"
+			Expect(client.Update(ctx, ac)).Should(Succeed())
+
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(meta.FindStatusCondition(getAdminConsole().Status.Conditions, adminConsoleApi.ConditionStalled)).Should(BeNil())
+			expectCondition(adminConsoleApi.ConditionReady, metav1.ConditionTrue, adminConsoleApi.ReasonReconcileSucceeded)
+		})
+	})
+
+	Context("when other EDPComponents are published in the namespace", func() {
+		componentKey := func(name string) k8sClient.ObjectKey {
+			return k8sClient.ObjectKey{Namespace: namespace, Name: name}
+		}
+
+		It("injects their URLs and follows a moved URL", func() {
+			platform.EDPComponents[componentKey("sonar")] = "https://sonar.example.com"
+			platform.EDPComponents[componentKey("gerrit-ui")] = "https://gerrit.example.com"
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			env := platform.Env[request.NamespacedName]
+			Expect(env).Should(ContainElements(
+				corev1.EnvVar{Name: "EDP_COMPONENT_SONAR_URL", Value: "https://sonar.example.com"},
+				corev1.EnvVar{Name: "EDP_COMPONENT_GERRIT_UI_URL", Value: "https://gerrit.example.com"},
+			))
+			Expect(env).ShouldNot(ContainElement(WithTransform(envName, Equal("EDP_COMPONENT_EDP_ADMIN_CONSOLE_URL"))))
+
+			platform.EDPComponents[componentKey("sonar")] = "https://sonar.example.org"
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(platform.Env[request.NamespacedName]).Should(ContainElement(
+				corev1.EnvVar{Name: "EDP_COMPONENT_SONAR_URL", Value: "https://sonar.example.org"}))
+		})
+
+		It("reports EDPComponents which cannot be listed", func() {
+			platform.SetError("ListEDPComponents", errors.New("edpcomponents.v1.edp.epam.com is forbidden"))
+			createAdminConsole()
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionEnvPatched, metav1.ConditionFalse, adminConsoleApi.ReasonEDPComponentsNotListed)
+			Expect(platform.Called("PatchDeploymentEnv")).Should(BeFalse())
+		})
+	})
+
+	Context("when the settings are rendered into a ConfigMap", func() {
+		configMapKey := func() k8sClient.ObjectKey {
+			return k8sClient.ObjectKey{Namespace: namespace, Name: acName + "-config"}
+		}
+
+		It("references the ConfigMap and changes the checksum only with the content", func() {
+			createAdminConsole(func(ac *adminConsoleApi.AdminConsole) {
+				ac.Spec.ConfigSource = adminConsoleApi.ConfigSourceConfigMap
+				ac.Spec.Features = &adminConsoleApi.FeaturesSpec{BuildTools: []string{"maven"}}
+			})
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			data := platform.ConfigMaps[configMapKey()]
+			Expect(data).Should(HaveKeyWithValue("KEYCLOAK_URL", "https://keycloak.example.com/auth/realms/edp"))
+			Expect(data).Should(HaveKeyWithValue("BUILD_TOOLS", "maven"))
+			Expect(data).ShouldNot(HaveKey("KEYCLOAK_CLIENT_SECRET"))
+			config := platform.Configs[request.NamespacedName]
+			Expect(config.Env).Should(ConsistOf(
+				WithTransform(envName, Equal("KEYCLOAK_CLIENT_ID")),
+				WithTransform(envName, Equal("KEYCLOAK_CLIENT_SECRET")),
+			))
+			Expect(config.ConfigMapName).Should(Equal(configMapKey().Name))
+			Expect(config.Checksum).Should(Equal(platformHelper.ConfigChecksum(data)))
+			checksum := config.Checksum
+
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(platform.Configs[request.NamespacedName].Checksum).Should(Equal(checksum))
+
+			ac := getAdminConsole()
+			ac.Spec.Features.BuildTools = []string{"maven", "gradle"}
+			Expect(client.Update(ctx, ac)).Should(Succeed())
+
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(platform.ConfigMaps[configMapKey()]).Should(HaveKeyWithValue("BUILD_TOOLS", "maven,gradle"))
+			Expect(platform.Configs[request.NamespacedName].Checksum).ShouldNot(Equal(checksum))
+		})
+
+		It("deletes the ConfigMap once the settings are set as env again", func() {
+			createAdminConsole(func(ac *adminConsoleApi.AdminConsole) {
+				ac.Spec.ConfigSource = adminConsoleApi.ConfigSourceConfigMap
+			})
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(platform.ConfigMaps).Should(HaveKey(configMapKey()))
+
+			ac := getAdminConsole()
+			ac.Spec.ConfigSource = adminConsoleApi.ConfigSourceEnv
+			Expect(client.Update(ctx, ac)).Should(Succeed())
+
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(platform.ConfigMaps).ShouldNot(HaveKey(configMapKey()))
+			config := platform.Configs[request.NamespacedName]
+			Expect(config.ConfigMapName).Should(BeEmpty())
+			Expect(config.Env).Should(ContainElement(corev1.EnvVar{Name: "PG_HOST", Value: "edp-db"}))
+		})
+
+		It("reports a ConfigMap which cannot be reconciled", func() {
+			platform.SetError("CreateOrUpdateConfigMap", errors.New("configmaps is forbidden"))
+			createAdminConsole(func(ac *adminConsoleApi.AdminConsole) {
+				ac.Spec.ConfigSource = adminConsoleApi.ConfigSourceConfigMap
+			})
+
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).Should(HaveOccurred())
+
+			expectCondition(adminConsoleApi.ConditionEnvPatched, metav1.ConditionFalse, adminConsoleApi.ReasonEnvPatchFailed)
+			Expect(platform.Called("PatchDeploymentEnv")).Should(BeFalse())
+		})
+	})
+
+	Context("when the AdminConsole is deleted", func() {
+		It("removes the dependent objects before the finalizer", func() {
+			createAdminConsole()
+			_, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			platform.KeycloakClientDeleted = false
+			Expect(client.Delete(ctx, getAdminConsole())).Should(Succeed())
+
+			result, err := r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(result.RequeueAfter).Should(Equal(adminconsole.CleanupRequeueTime * time.Second))
+			Expect(getAdminConsole().Finalizers).Should(ContainElement(adminconsole.FinalizerName))
+
+			platform.KeycloakClientDeleted = true
+			_, err = r.Reconcile(ctx, request)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(platform.EDPComponents).Should(BeEmpty())
+			Expect(platform.KeycloakClients).Should(BeEmpty())
+			Expect(platform.Secrets).Should(BeEmpty())
+			err = client.Get(ctx, request.NamespacedName, &adminConsoleApi.AdminConsole{})
+			Expect(k8sErrors.IsNotFound(err)).Should(BeTrue())
+		})
+	})
+})
+
+// envName is the name of an env entry, for matching entries regardless of their values.
+func envName(e corev1.EnvVar) string {
+	return e.Name
+}
+
+// createKeycloak creates the Keycloak and its realm the admin console client belongs to, and returns
+// the owner references the Keycloak operator sets on a KeycloakClient of the realm.
+func createKeycloak(ctx context.Context, namespace string) []metav1.OwnerReference {
+	keycloak := &keycloakV1Api.Keycloak{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: namespace},
+		Spec:       keycloakV1Api.KeycloakSpec{Url: "https://keycloak.example.com", Secret: "keycloak-admin"},
+	}
+	Expect(client.Create(ctx, keycloak)).Should(Succeed())
+
+	realm := &keycloakV1Api.KeycloakRealm{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "main",
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: keycloakV1Api.SchemeGroupVersion.String(),
+				Kind:       "Keycloak",
+				Name:       keycloak.Name,
+				UID:        keycloak.UID,
+			}},
+		},
+		Spec: keycloakV1Api.KeycloakRealmSpec{RealmName: "edp"},
+	}
+	Expect(client.Create(ctx, realm)).Should(Succeed())
+
+	return []metav1.OwnerReference{{
+		APIVersion: keycloakV1Api.SchemeGroupVersion.String(),
+		Kind:       "KeycloakRealm",
+		Name:       realm.Name,
+		UID:        realm.UID,
+	}}
+}
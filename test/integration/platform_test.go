@@ -0,0 +1,139 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	platformHelper "github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/helper"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/service/platform/kubernetes"
+)
+
+// legacyFieldManager stands for the field manager of an earlier operator version, which updated the env in place.
+const legacyFieldManager = "edp-admin-console-operator"
+
+var _ = Describe("Kubernetes platform service", func() {
+	var (
+		ctx     context.Context
+		service *kubernetes.K8SService
+		ac      *adminConsoleApi.AdminConsole
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "admin-console-platform-"}}
+		Expect(client.Create(ctx, ns)).Should(Succeed())
+
+		service = &kubernetes.K8SService{}
+		Expect(service.Init(env.Config, scheme, &client)).Should(Succeed())
+
+		ac = &adminConsoleApi.AdminConsole{
+			ObjectMeta: metav1.ObjectMeta{Name: acName, Namespace: ns.Name},
+			Spec: adminConsoleApi.AdminConsoleSpec{
+				EdpSpec: adminConsoleApi.EdpSpec{Name: "edp", DnsWildcard: "example.com", TestReportTools: "Allure"},
+			},
+		}
+		Expect(client.Create(ctx, ac)).Should(Succeed())
+	})
+
+	getDeployment := func() *appsv1.Deployment {
+		d := &appsv1.Deployment{}
+		Expect(client.Get(ctx, k8sClient.ObjectKey{Namespace: ac.Namespace, Name: ac.Name}, d)).Should(Succeed())
+		return d
+	}
+
+	getEnv := func() []corev1.EnvVar {
+		container, err := platformHelper.SelectContainer(getDeployment().Spec.Template.Spec.Containers, ac.Name)
+		Expect(err).ShouldNot(HaveOccurred())
+		return container.Env
+	}
+
+	// ownsEnv reports whether the field manager owns the env entry.
+	ownsEnv := func(manager, name string) bool {
+		key := fmt.Sprintf(`"k:{\"name\":\"%s\"}"`, name)
+		for _, m := range getDeployment().ManagedFields {
+			if m.Manager == manager && m.FieldsV1 != nil && strings.Contains(string(m.FieldsV1.Raw), key) {
+				return true
+			}
+		}
+		return false
+	}
+
+	Context("when the env has been set by an earlier operator version", func() {
+		BeforeEach(func() {
+			template := platformHelper.GeneratePodTemplate(*ac, append(platformHelper.GenerateBaseEnv(*ac, "kubernetes"),
+				corev1.EnvVar{Name: "PG_HOST", Value: "old-db"},
+				corev1.EnvVar{Name: "OBSOLETE", Value: "1"},
+			))
+			d := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: ac.Name, Namespace: ac.Namespace},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: platformHelper.GenerateLabels(ac.Name)},
+					Template: template,
+				},
+			}
+			Expect(client.Create(ctx, d, k8sClient.FieldOwner(legacyFieldManager))).Should(Succeed())
+		})
+
+		It("keeps the env until the first apply", func() {
+			Expect(service.CreateOrUpdateDeployment(ctx, *ac)).Should(Succeed())
+
+			Expect(getEnv()).Should(ContainElements(
+				corev1.EnvVar{Name: "PG_HOST", Value: "old-db"},
+				corev1.EnvVar{Name: "OBSOLETE", Value: "1"},
+			))
+		})
+
+		It("takes the applied env over and removes what is no longer generated", func() {
+			Expect(service.PatchDeploymentEnv(ctx, *ac, platformHelper.WorkloadConfig{Env: []corev1.EnvVar{
+				{Name: "PG_HOST", Value: "edp-db"},
+				{Name: "KEYCLOAK_URL", Value: "https://keycloak.example.com/auth/realms/edp"},
+			}})).Should(Succeed())
+
+			Expect(getEnv()).Should(ContainElement(corev1.EnvVar{Name: "PG_HOST", Value: "edp-db"}))
+			Expect(ownsEnv(legacyFieldManager, "PG_HOST")).Should(BeFalse())
+			Expect(ownsEnv(legacyFieldManager, "OBSOLETE")).Should(BeTrue())
+			Expect(platformHelper.GetAppliedEnv(getDeployment().ManagedFields, ac.Name)).Should(Equal([]string{"KEYCLOAK_URL", "PG_HOST"}))
+
+			Expect(service.CreateOrUpdateDeployment(ctx, *ac)).Should(Succeed())
+			Expect(getEnv()).Should(ContainElements(
+				corev1.EnvVar{Name: "PG_HOST", Value: "edp-db"},
+				corev1.EnvVar{Name: "KEYCLOAK_URL", Value: "https://keycloak.example.com/auth/realms/edp"},
+			))
+			Expect(getEnv()).ShouldNot(ContainElement(WithTransform(envName, Equal("OBSOLETE"))))
+
+			Expect(service.PatchDeploymentEnv(ctx, *ac, platformHelper.WorkloadConfig{Env: []corev1.EnvVar{
+				{Name: "KEYCLOAK_URL", Value: "https://keycloak.example.com/auth/realms/edp"},
+			}})).Should(Succeed())
+			Expect(getEnv()).ShouldNot(ContainElement(WithTransform(envName, Equal("PG_HOST"))))
+		})
+	})
+
+	Context("when the generated secrets are deleted", func() {
+		It("keeps the secret of another admin console", func() {
+			other := &adminConsoleApi.AdminConsole{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-admin-console", Namespace: ac.Namespace},
+				Spec:       ac.Spec,
+			}
+			Expect(client.Create(ctx, other)).Should(Succeed())
+			Expect(service.CreateSecret(ctx, *other, "shared", map[string][]byte{"password": []byte("secret")})).Should(Succeed())
+
+			Expect(service.DeleteSecret(ctx, *ac, "shared")).Should(Succeed())
+			Expect(client.Get(ctx, k8sClient.ObjectKey{Namespace: ac.Namespace, Name: "shared"}, &corev1.Secret{})).Should(Succeed())
+
+			Expect(service.DeleteSecret(ctx, *other, "shared")).Should(Succeed())
+			err := client.Get(ctx, k8sClient.ObjectKey{Namespace: ac.Namespace, Name: "shared"}, &corev1.Secret{})
+			Expect(k8sErrors.IsNotFound(err)).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	edpCompApi "github.com/epam/edp-component-operator/pkg/apis/v1/v1"
+	keycloakV1Api "github.com/epam/edp-keycloak-operator/pkg/apis/v1/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	adminConsoleApi "github.com/epam/edp-admin-console-operator/v2/pkg/apis/edp/v1"
+	"github.com/epam/edp-admin-console-operator/v2/pkg/helper"
+)
+
+var (
+	env    *envtest.Environment
+	client k8sClient.Client
+	scheme *runtime.Scheme
+)
+
+// TestAdminConsoleIntegration runs the reconciler against the local API server of envtest. The control plane
+// binaries are looked up in KUBEBUILDER_ASSETS, the suite is skipped without them.
+func TestAdminConsoleIntegration(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("Skipping integration tests, KUBEBUILDER_ASSETS is not set.")
+	}
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Admin Console Integration Suite")
+}
+
+var _ = BeforeSuite(func() {
+	env = &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "deploy-templates", "crds"),
+			filepath.Join("testdata", "crds"),
+		},
+		ErrorIfCRDPathMissing: true,
+	}
+
+	cfg, err := env.Start()
+	Expect(err).ShouldNot(HaveOccurred())
+
+	scheme = runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(adminConsoleApi.AddToScheme(scheme))
+	utilruntime.Must(keycloakV1Api.AddToScheme(scheme))
+	utilruntime.Must(edpCompApi.AddToScheme(scheme))
+
+	client, err = k8sClient.New(cfg, k8sClient.Options{Scheme: scheme})
+	Expect(err).ShouldNot(HaveOccurred())
+
+	Expect(copyIcon()).Should(Succeed())
+})
+
+var _ = AfterSuite(func() {
+	if env != nil {
+		Expect(env.Stop()).Should(Succeed())
+	}
+})
+
+// copyIcon puts the admin console icon next to the test binary, where the operator looks it up when
+// running outside of a cluster.
+func copyIcon() error {
+	dir, err := helper.GetExecutableFilePath()
+	if err != nil {
+		return err
+	}
+
+	icon, err := ioutil.ReadFile(filepath.Join("..", "..", "build", "configs", "img", "admin-console.svg"))
+	if err != nil {
+		return err
+	}
+
+	imgDir := filepath.Join(dir, "..", "configs", "img")
+	if err := os.MkdirAll(imgDir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(imgDir, "admin-console.svg"), icon, 0644)
+}